@@ -4,19 +4,27 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"database/sql"
 
 	_ "github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 
+	"github.com/AssetPortal/assets-api/pkg/adapters/apikeys"
 	"github.com/AssetPortal/assets-api/pkg/adapters/assets"
 	"github.com/AssetPortal/assets-api/pkg/adapters/auth"
+	"github.com/AssetPortal/assets-api/pkg/adapters/delegates"
+	"github.com/AssetPortal/assets-api/pkg/adapters/sessions"
 	"github.com/AssetPortal/assets-api/pkg/adapters/storage"
 	"github.com/AssetPortal/assets-api/pkg/adapters/tokens"
 	"github.com/AssetPortal/assets-api/pkg/app"
 	"github.com/AssetPortal/assets-api/pkg/config"
 	"github.com/AssetPortal/assets-api/pkg/middleware"
+	"github.com/AssetPortal/assets-api/pkg/model"
 	"github.com/AssetPortal/assets-api/pkg/service"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
@@ -36,6 +44,20 @@ func main() {
 	}
 	logger := logrus.New()
 	logger.SetLevel(lvl)
+	networks := model.DefaultNetworks()
+	if cfg.NetworksConfiguration.File != "" {
+		networks, err = model.LoadNetworks(cfg.NetworksConfiguration.File)
+		if err != nil {
+			log.Fatalf("error loading the networks: %v", err)
+		}
+	}
+	for i, admin := range cfg.AdminAddresses {
+		canonical, err := model.CanonicalAddress(admin)
+		if err != nil {
+			log.Fatalf("error parsing the admin address '%s': %v", admin, err)
+		}
+		cfg.AdminAddresses[i] = canonical
+	}
 
 	// Open a database connection
 	sqlDB, err := sql.Open("postgres", cfg.DatabaseConfiguration.URL)
@@ -58,12 +80,34 @@ func main() {
 	// Initialize Bun with database/sql and PostgreSQL dialect
 	db := bun.NewDB(sqlDB, pgdialect.New())
 	tokensRepository := tokens.NewTokensRepository(db)
+	sessionsRepository := sessions.NewSessionsRepository(db)
 	assetsRepository := assets.NewAssetsRepository(db)
-	httpClient := &http.Client{
-		Timeout: cfg.AuthConfiguration.HTTPTimeout,
+	delegatesRepository := delegates.NewDelegatesRepository(db)
+	apiKeysRepository := apikeys.NewAPIKeysRepository(db)
+	var authClient auth.Client
+	switch cfg.AuthConfiguration.Provider {
+	case auth.PROVIDER_NATIVE:
+		authClient = auth.NewNativeClient()
+	case auth.PROVIDER_HTTP:
+		httpClient := &http.Client{
+			Timeout: cfg.AuthConfiguration.HTTPTimeout,
+		}
+		authClient = auth.NewPolkadotClient(cfg.AuthConfiguration.APIURL, httpClient)
+	default:
+		log.Fatalf("unknown auth provider '%s'", cfg.AuthConfiguration.Provider)
+	}
+	authMiddleware := middleware.NewPolkadotAuth(
+		tokensRepository,
+		authClient,
+		cfg.AuthConfiguration.Enabled,
+		cfg.AuthConfiguration.DevAddress,
+	).WithNetworks(networks).WithProvider(middleware.NewAPIKeyAuth(apiKeysRepository).WithNetworks(networks))
+	if cfg.AuthConfiguration.Domain != "" {
+		authMiddleware.WithSIWS(cfg.AuthConfiguration.Domain, cfg.AuthConfiguration.URI)
+	}
+	if cfg.SessionConfiguration.Enabled() {
+		authMiddleware.WithSessions(sessionsRepository, cfg.SessionConfiguration.Secret)
 	}
-	authClient := auth.NewPolkadotClient(cfg.AuthConfiguration.APIURL, httpClient)
-	authMiddleware := middleware.NewPolkadotAuth(tokensRepository, authClient, cfg.AuthConfiguration.Enabled)
 
 	staticCreds := credentials.NewStaticCredentialsProvider(
 		cfg.BucketConfiguration.AccessKey,
@@ -79,9 +123,39 @@ func main() {
 		log.Fatalf("failed to load AWS config: %v", err)
 	}
 	storageClient := storage.NewS3Uploader(awsCfg, cfg.BucketConfiguration.Name)
-	assetsApp := app.NewAssetsApp(cfg, db, tokensRepository, assetsRepository, storageClient, logger)
-	service := service.NewService(assetsApp, authMiddleware)
+	assetsApp := app.NewAssetsApp(cfg, db, tokensRepository, assetsRepository, delegatesRepository, storageClient, logger).WithNetworks(networks)
+	sessionsApp := app.NewSessionsApp(cfg, sessionsRepository, logger)
+	delegatesApp := app.NewDelegatesApp(cfg, delegatesRepository, logger)
+	apiKeysApp := app.NewAPIKeysApp(cfg, apiKeysRepository, logger)
+	service := service.NewService(assetsApp, sessionsApp, delegatesApp, apiKeysApp, authMiddleware)
 
 	service.Setup()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var workers sync.WaitGroup
+	if cfg.JanitorConfiguration.Enabled {
+		janitor := app.NewJanitor(cfg, tokensRepository, assetsRepository, logger)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			janitor.Run(ctx)
+		}()
+	}
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTPTimeout)
+		defer cancel()
+		if err := service.Stop(shutdownCtx); err != nil {
+			logger.Errorf("error shutting down the server: %v", err)
+		}
+	}()
+
 	service.Start()
+	stop()
+	workers.Wait()
 }
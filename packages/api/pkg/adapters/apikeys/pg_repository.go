@@ -0,0 +1,72 @@
+package apikeys
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/uptrace/bun"
+)
+
+type APIKeysRepository struct {
+	db *bun.DB
+}
+
+func NewAPIKeysRepository(db *bun.DB) *APIKeysRepository {
+	return &APIKeysRepository{db: db}
+}
+
+func (repo *APIKeysRepository) CreateAPIKey(ctx context.Context, key *model.APIKey) (*model.APIKey, error) {
+	_, err := repo.db.NewInsert().Model(key).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store API key in database: %v", err)
+	}
+	return key, nil
+}
+
+func (repo *APIKeysRepository) GetAPIKey(ctx context.Context, id string) (*model.APIKey, error) {
+	var dbKey model.APIKey
+	err := repo.db.NewSelect().Model(&dbKey).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query API key: %v", err)
+	}
+	return &dbKey, nil
+}
+
+func (repo *APIKeysRepository) GetAPIKeys(ctx context.Context, owner string) ([]*model.APIKey, error) {
+	dbKeys := []*model.APIKey{}
+	err := repo.db.NewSelect().Model(&dbKeys).
+		Where("owner = ?", owner).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API keys: %v", err)
+	}
+	return dbKeys, nil
+}
+
+func (repo *APIKeysRepository) RevokeAPIKey(ctx context.Context, id, owner string) error {
+	res, err := repo.db.NewUpdate().Model((*model.APIKey)(nil)).
+		Set("revoked_at = now()").
+		Where("id = ?", id).
+		Where("owner = ?", owner).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %v", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("API key '%s' of owner '%s' does not exist", id, owner)
+	}
+	return nil
+}
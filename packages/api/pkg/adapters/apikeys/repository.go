@@ -0,0 +1,14 @@
+package apikeys
+
+import (
+	"context"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+)
+
+type Repository interface {
+	CreateAPIKey(ctx context.Context, key *model.APIKey) (*model.APIKey, error)
+	GetAPIKey(ctx context.Context, id string) (*model.APIKey, error)
+	GetAPIKeys(ctx context.Context, owner string) ([]*model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id, owner string) error
+}
@@ -4,7 +4,9 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
+	assets "github.com/AssetPortal/assets-api/pkg/adapters/assets"
 	model "github.com/AssetPortal/assets-api/pkg/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -22,6 +24,112 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
+// CancelPendingTransfers provides a mock function with given fields: ctx, assetID, address, at
+func (_m *Repository) CancelPendingTransfers(ctx context.Context, assetID string, address string, at time.Time) error {
+	ret := _m.Called(ctx, assetID, address, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelPendingTransfers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, assetID, address, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_CancelPendingTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelPendingTransfers'
+type Repository_CancelPendingTransfers_Call struct {
+	*mock.Call
+}
+
+// CancelPendingTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assetID string
+//   - address string
+//   - at time.Time
+func (_e *Repository_Expecter) CancelPendingTransfers(ctx interface{}, assetID interface{}, address interface{}, at interface{}) *Repository_CancelPendingTransfers_Call {
+	return &Repository_CancelPendingTransfers_Call{Call: _e.mock.On("CancelPendingTransfers", ctx, assetID, address, at)}
+}
+
+func (_c *Repository_CancelPendingTransfers_Call) Run(run func(ctx context.Context, assetID string, address string, at time.Time)) *Repository_CancelPendingTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Repository_CancelPendingTransfers_Call) Return(_a0 error) *Repository_CancelPendingTransfers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_CancelPendingTransfers_Call) RunAndReturn(run func(context.Context, string, string, time.Time) error) *Repository_CancelPendingTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountAssets provides a mock function with given fields: ctx, filters
+func (_m *Repository) CountAssets(ctx context.Context, filters *model.GetAssetsInput) (int, error) {
+	ret := _m.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAssets")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetAssetsInput) (int, error)); ok {
+		return rf(ctx, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetAssetsInput) int); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.GetAssetsInput) error); ok {
+		r1 = rf(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_CountAssets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountAssets'
+type Repository_CountAssets_Call struct {
+	*mock.Call
+}
+
+// CountAssets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters *model.GetAssetsInput
+func (_e *Repository_Expecter) CountAssets(ctx interface{}, filters interface{}) *Repository_CountAssets_Call {
+	return &Repository_CountAssets_Call{Call: _e.mock.On("CountAssets", ctx, filters)}
+}
+
+func (_c *Repository_CountAssets_Call) Run(run func(ctx context.Context, filters *model.GetAssetsInput)) *Repository_CountAssets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.GetAssetsInput))
+	})
+	return _c
+}
+
+func (_c *Repository_CountAssets_Call) Return(_a0 int, _a1 error) *Repository_CountAssets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_CountAssets_Call) RunAndReturn(run func(context.Context, *model.GetAssetsInput) (int, error)) *Repository_CountAssets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAsset provides a mock function with given fields: ctx, asset
 func (_m *Repository) CreateAsset(ctx context.Context, asset *model.Asset) (*model.Asset, error) {
 	ret := _m.Called(ctx, asset)
@@ -81,17 +189,111 @@ func (_c *Repository_CreateAsset_Call) RunAndReturn(run func(context.Context, *m
 	return _c
 }
 
-// DeleteAsset provides a mock function with given fields: ctx, id, address
-func (_m *Repository) DeleteAsset(ctx context.Context, id string, address string) error {
-	ret := _m.Called(ctx, id, address)
+// CreateRevision provides a mock function with given fields: ctx, revision
+func (_m *Repository) CreateRevision(ctx context.Context, revision *model.AssetRevision) error {
+	ret := _m.Called(ctx, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRevision")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AssetRevision) error); ok {
+		r0 = rf(ctx, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_CreateRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRevision'
+type Repository_CreateRevision_Call struct {
+	*mock.Call
+}
+
+// CreateRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - revision *model.AssetRevision
+func (_e *Repository_Expecter) CreateRevision(ctx interface{}, revision interface{}) *Repository_CreateRevision_Call {
+	return &Repository_CreateRevision_Call{Call: _e.mock.On("CreateRevision", ctx, revision)}
+}
+
+func (_c *Repository_CreateRevision_Call) Run(run func(ctx context.Context, revision *model.AssetRevision)) *Repository_CreateRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.AssetRevision))
+	})
+	return _c
+}
+
+func (_c *Repository_CreateRevision_Call) Return(_a0 error) *Repository_CreateRevision_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_CreateRevision_Call) RunAndReturn(run func(context.Context, *model.AssetRevision) error) *Repository_CreateRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTransfer provides a mock function with given fields: ctx, transfer
+func (_m *Repository) CreateTransfer(ctx context.Context, transfer *model.AssetTransfer) error {
+	ret := _m.Called(ctx, transfer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTransfer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AssetTransfer) error); ok {
+		r0 = rf(ctx, transfer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_CreateTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTransfer'
+type Repository_CreateTransfer_Call struct {
+	*mock.Call
+}
+
+// CreateTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transfer *model.AssetTransfer
+func (_e *Repository_Expecter) CreateTransfer(ctx interface{}, transfer interface{}) *Repository_CreateTransfer_Call {
+	return &Repository_CreateTransfer_Call{Call: _e.mock.On("CreateTransfer", ctx, transfer)}
+}
+
+func (_c *Repository_CreateTransfer_Call) Run(run func(ctx context.Context, transfer *model.AssetTransfer)) *Repository_CreateTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.AssetTransfer))
+	})
+	return _c
+}
+
+func (_c *Repository_CreateTransfer_Call) Return(_a0 error) *Repository_CreateTransfer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_CreateTransfer_Call) RunAndReturn(run func(context.Context, *model.AssetTransfer) error) *Repository_CreateTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAsset provides a mock function with given fields: ctx, id, address, version
+func (_m *Repository) DeleteAsset(ctx context.Context, id string, address string, version *int) error {
+	ret := _m.Called(ctx, id, address, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteAsset")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, id, address)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *int) error); ok {
+		r0 = rf(ctx, id, address, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -108,13 +310,14 @@ type Repository_DeleteAsset_Call struct {
 //   - ctx context.Context
 //   - id string
 //   - address string
-func (_e *Repository_Expecter) DeleteAsset(ctx interface{}, id interface{}, address interface{}) *Repository_DeleteAsset_Call {
-	return &Repository_DeleteAsset_Call{Call: _e.mock.On("DeleteAsset", ctx, id, address)}
+//   - version *int
+func (_e *Repository_Expecter) DeleteAsset(ctx interface{}, id interface{}, address interface{}, version interface{}) *Repository_DeleteAsset_Call {
+	return &Repository_DeleteAsset_Call{Call: _e.mock.On("DeleteAsset", ctx, id, address, version)}
 }
 
-func (_c *Repository_DeleteAsset_Call) Run(run func(ctx context.Context, id string, address string)) *Repository_DeleteAsset_Call {
+func (_c *Repository_DeleteAsset_Call) Run(run func(ctx context.Context, id string, address string, version *int)) *Repository_DeleteAsset_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*int))
 	})
 	return _c
 }
@@ -124,7 +327,7 @@ func (_c *Repository_DeleteAsset_Call) Return(_a0 error) *Repository_DeleteAsset
 	return _c
 }
 
-func (_c *Repository_DeleteAsset_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_DeleteAsset_Call {
+func (_c *Repository_DeleteAsset_Call) RunAndReturn(run func(context.Context, string, string, *int) error) *Repository_DeleteAsset_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -247,49 +450,721 @@ func (_c *Repository_GetAssets_Call) RunAndReturn(run func(context.Context, *mod
 	return _c
 }
 
-// UpdateAsset provides a mock function with given fields: ctx, asset
-func (_m *Repository) UpdateAsset(ctx context.Context, asset *model.Asset) error {
-	ret := _m.Called(ctx, asset)
+// GetAssetsByIDs provides a mock function with given fields: ctx, ids, fields
+func (_m *Repository) GetAssetsByIDs(ctx context.Context, ids []string, fields []string) ([]*model.Asset, error) {
+	ret := _m.Called(ctx, ids, fields)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateAsset")
+		panic("no return value specified for GetAssetsByIDs")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.Asset) error); ok {
-		r0 = rf(ctx, asset)
+	var r0 []*model.Asset
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string) ([]*model.Asset, error)); ok {
+		return rf(ctx, ids, fields)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string) []*model.Asset); ok {
+		r0 = rf(ctx, ids, fields)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Asset)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, []string, []string) error); ok {
+		r1 = rf(ctx, ids, fields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Repository_UpdateAsset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAsset'
-type Repository_UpdateAsset_Call struct {
+// Repository_GetAssetsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssetsByIDs'
+type Repository_GetAssetsByIDs_Call struct {
 	*mock.Call
 }
 
-// UpdateAsset is a helper method to define mock.On call
+// GetAssetsByIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - asset *model.Asset
-func (_e *Repository_Expecter) UpdateAsset(ctx interface{}, asset interface{}) *Repository_UpdateAsset_Call {
-	return &Repository_UpdateAsset_Call{Call: _e.mock.On("UpdateAsset", ctx, asset)}
+//   - ids []string
+//   - fields []string
+func (_e *Repository_Expecter) GetAssetsByIDs(ctx interface{}, ids interface{}, fields interface{}) *Repository_GetAssetsByIDs_Call {
+	return &Repository_GetAssetsByIDs_Call{Call: _e.mock.On("GetAssetsByIDs", ctx, ids, fields)}
 }
 
-func (_c *Repository_UpdateAsset_Call) Run(run func(ctx context.Context, asset *model.Asset)) *Repository_UpdateAsset_Call {
+func (_c *Repository_GetAssetsByIDs_Call) Run(run func(ctx context.Context, ids []string, fields []string)) *Repository_GetAssetsByIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*model.Asset))
+		run(args[0].(context.Context), args[1].([]string), args[2].([]string))
 	})
 	return _c
 }
 
-func (_c *Repository_UpdateAsset_Call) Return(_a0 error) *Repository_UpdateAsset_Call {
+func (_c *Repository_GetAssetsByIDs_Call) Return(_a0 []*model.Asset, _a1 error) *Repository_GetAssetsByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_GetAssetsByIDs_Call) RunAndReturn(run func(context.Context, []string, []string) ([]*model.Asset, error)) *Repository_GetAssetsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingTransfer provides a mock function with given fields: ctx, assetID
+func (_m *Repository) GetPendingTransfer(ctx context.Context, assetID string) (*model.AssetTransfer, error) {
+	ret := _m.Called(ctx, assetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTransfer")
+	}
+
+	var r0 *model.AssetTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.AssetTransfer, error)); ok {
+		return rf(ctx, assetID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.AssetTransfer); ok {
+		r0 = rf(ctx, assetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AssetTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, assetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_GetPendingTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingTransfer'
+type Repository_GetPendingTransfer_Call struct {
+	*mock.Call
+}
+
+// GetPendingTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assetID string
+func (_e *Repository_Expecter) GetPendingTransfer(ctx interface{}, assetID interface{}) *Repository_GetPendingTransfer_Call {
+	return &Repository_GetPendingTransfer_Call{Call: _e.mock.On("GetPendingTransfer", ctx, assetID)}
+}
+
+func (_c *Repository_GetPendingTransfer_Call) Run(run func(ctx context.Context, assetID string)) *Repository_GetPendingTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_GetPendingTransfer_Call) Return(_a0 *model.AssetTransfer, _a1 error) *Repository_GetPendingTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_GetPendingTransfer_Call) RunAndReturn(run func(context.Context, string) (*model.AssetTransfer, error)) *Repository_GetPendingTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevision provides a mock function with given fields: ctx, assetID, revision
+func (_m *Repository) GetRevision(ctx context.Context, assetID string, revision int) (*model.AssetRevision, error) {
+	ret := _m.Called(ctx, assetID, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 *model.AssetRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*model.AssetRevision, error)); ok {
+		return rf(ctx, assetID, revision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *model.AssetRevision); ok {
+		r0 = rf(ctx, assetID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AssetRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, assetID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_GetRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevision'
+type Repository_GetRevision_Call struct {
+	*mock.Call
+}
+
+// GetRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assetID string
+//   - revision int
+func (_e *Repository_Expecter) GetRevision(ctx interface{}, assetID interface{}, revision interface{}) *Repository_GetRevision_Call {
+	return &Repository_GetRevision_Call{Call: _e.mock.On("GetRevision", ctx, assetID, revision)}
+}
+
+func (_c *Repository_GetRevision_Call) Run(run func(ctx context.Context, assetID string, revision int)) *Repository_GetRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_GetRevision_Call) Return(_a0 *model.AssetRevision, _a1 error) *Repository_GetRevision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_GetRevision_Call) RunAndReturn(run func(context.Context, string, int) (*model.AssetRevision, error)) *Repository_GetRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevisionAt provides a mock function with given fields: ctx, assetID, at
+func (_m *Repository) GetRevisionAt(ctx context.Context, assetID string, at time.Time) (*model.AssetRevision, error) {
+	ret := _m.Called(ctx, assetID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisionAt")
+	}
+
+	var r0 *model.AssetRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*model.AssetRevision, error)); ok {
+		return rf(ctx, assetID, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *model.AssetRevision); ok {
+		r0 = rf(ctx, assetID, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AssetRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, assetID, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_GetRevisionAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevisionAt'
+type Repository_GetRevisionAt_Call struct {
+	*mock.Call
+}
+
+// GetRevisionAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assetID string
+//   - at time.Time
+func (_e *Repository_Expecter) GetRevisionAt(ctx interface{}, assetID interface{}, at interface{}) *Repository_GetRevisionAt_Call {
+	return &Repository_GetRevisionAt_Call{Call: _e.mock.On("GetRevisionAt", ctx, assetID, at)}
+}
+
+func (_c *Repository_GetRevisionAt_Call) Run(run func(ctx context.Context, assetID string, at time.Time)) *Repository_GetRevisionAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Repository_GetRevisionAt_Call) Return(_a0 *model.AssetRevision, _a1 error) *Repository_GetRevisionAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_GetRevisionAt_Call) RunAndReturn(run func(context.Context, string, time.Time) (*model.AssetRevision, error)) *Repository_GetRevisionAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevisions provides a mock function with given fields: ctx, assetID, limit, offset
+func (_m *Repository) GetRevisions(ctx context.Context, assetID string, limit int, offset int) ([]*model.AssetRevision, error) {
+	ret := _m.Called(ctx, assetID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisions")
+	}
+
+	var r0 []*model.AssetRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*model.AssetRevision, error)); ok {
+		return rf(ctx, assetID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*model.AssetRevision); ok {
+		r0 = rf(ctx, assetID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AssetRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, assetID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_GetRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevisions'
+type Repository_GetRevisions_Call struct {
+	*mock.Call
+}
+
+// GetRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assetID string
+//   - limit int
+//   - offset int
+func (_e *Repository_Expecter) GetRevisions(ctx interface{}, assetID interface{}, limit interface{}, offset interface{}) *Repository_GetRevisions_Call {
+	return &Repository_GetRevisions_Call{Call: _e.mock.On("GetRevisions", ctx, assetID, limit, offset)}
+}
+
+func (_c *Repository_GetRevisions_Call) Run(run func(ctx context.Context, assetID string, limit int, offset int)) *Repository_GetRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_GetRevisions_Call) Return(_a0 []*model.AssetRevision, _a1 error) *Repository_GetRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_GetRevisions_Call) RunAndReturn(run func(context.Context, string, int, int) ([]*model.AssetRevision, error)) *Repository_GetRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransfers provides a mock function with given fields: ctx, assetID, limit, offset
+func (_m *Repository) GetTransfers(ctx context.Context, assetID string, limit int, offset int) ([]*model.AssetTransfer, error) {
+	ret := _m.Called(ctx, assetID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransfers")
+	}
+
+	var r0 []*model.AssetTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*model.AssetTransfer, error)); ok {
+		return rf(ctx, assetID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*model.AssetTransfer); ok {
+		r0 = rf(ctx, assetID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AssetTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, assetID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_GetTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransfers'
+type Repository_GetTransfers_Call struct {
+	*mock.Call
+}
+
+// GetTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assetID string
+//   - limit int
+//   - offset int
+func (_e *Repository_Expecter) GetTransfers(ctx interface{}, assetID interface{}, limit interface{}, offset interface{}) *Repository_GetTransfers_Call {
+	return &Repository_GetTransfers_Call{Call: _e.mock.On("GetTransfers", ctx, assetID, limit, offset)}
+}
+
+func (_c *Repository_GetTransfers_Call) Run(run func(ctx context.Context, assetID string, limit int, offset int)) *Repository_GetTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_GetTransfers_Call) Return(_a0 []*model.AssetTransfer, _a1 error) *Repository_GetTransfers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_GetTransfers_Call) RunAndReturn(run func(context.Context, string, int, int) ([]*model.AssetTransfer, error)) *Repository_GetTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchAsset provides a mock function with given fields: ctx, asset, version
+func (_m *Repository) PatchAsset(ctx context.Context, asset *model.Asset, version *int) error {
+	ret := _m.Called(ctx, asset, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchAsset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Asset, *int) error); ok {
+		r0 = rf(ctx, asset, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_PatchAsset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchAsset'
+type Repository_PatchAsset_Call struct {
+	*mock.Call
+}
+
+// PatchAsset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - asset *model.Asset
+//   - version *int
+func (_e *Repository_Expecter) PatchAsset(ctx interface{}, asset interface{}, version interface{}) *Repository_PatchAsset_Call {
+	return &Repository_PatchAsset_Call{Call: _e.mock.On("PatchAsset", ctx, asset, version)}
+}
+
+func (_c *Repository_PatchAsset_Call) Run(run func(ctx context.Context, asset *model.Asset, version *int)) *Repository_PatchAsset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Asset), args[2].(*int))
+	})
+	return _c
+}
+
+func (_c *Repository_PatchAsset_Call) Return(_a0 error) *Repository_PatchAsset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_PatchAsset_Call) RunAndReturn(run func(context.Context, *model.Asset, *int) error) *Repository_PatchAsset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeletedAssets provides a mock function with given fields: ctx, deletedBefore, limit
+func (_m *Repository) PurgeDeletedAssets(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedAssets")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) (int64, error)); ok {
+		return rf(ctx, deletedBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int64); ok {
+		r0 = rf(ctx, deletedBefore, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, deletedBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_PurgeDeletedAssets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedAssets'
+type Repository_PurgeDeletedAssets_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedAssets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedBefore time.Time
+//   - limit int
+func (_e *Repository_Expecter) PurgeDeletedAssets(ctx interface{}, deletedBefore interface{}, limit interface{}) *Repository_PurgeDeletedAssets_Call {
+	return &Repository_PurgeDeletedAssets_Call{Call: _e.mock.On("PurgeDeletedAssets", ctx, deletedBefore, limit)}
+}
+
+func (_c *Repository_PurgeDeletedAssets_Call) Run(run func(ctx context.Context, deletedBefore time.Time, limit int)) *Repository_PurgeDeletedAssets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_PurgeDeletedAssets_Call) Return(_a0 int64, _a1 error) *Repository_PurgeDeletedAssets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_PurgeDeletedAssets_Call) RunAndReturn(run func(context.Context, time.Time, int) (int64, error)) *Repository_PurgeDeletedAssets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreAsset provides a mock function with given fields: ctx, id, address, deletedAfter
+func (_m *Repository) RestoreAsset(ctx context.Context, id string, address string, deletedAfter time.Time) (*model.Asset, error) {
+	ret := _m.Called(ctx, id, address, deletedAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAsset")
+	}
+
+	var r0 *model.Asset
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (*model.Asset, error)); ok {
+		return rf(ctx, id, address, deletedAfter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *model.Asset); ok {
+		r0 = rf(ctx, id, address, deletedAfter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Asset)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, id, address, deletedAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_RestoreAsset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreAsset'
+type Repository_RestoreAsset_Call struct {
+	*mock.Call
+}
+
+// RestoreAsset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - address string
+//   - deletedAfter time.Time
+func (_e *Repository_Expecter) RestoreAsset(ctx interface{}, id interface{}, address interface{}, deletedAfter interface{}) *Repository_RestoreAsset_Call {
+	return &Repository_RestoreAsset_Call{Call: _e.mock.On("RestoreAsset", ctx, id, address, deletedAfter)}
+}
+
+func (_c *Repository_RestoreAsset_Call) Run(run func(ctx context.Context, id string, address string, deletedAfter time.Time)) *Repository_RestoreAsset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Repository_RestoreAsset_Call) Return(_a0 *model.Asset, _a1 error) *Repository_RestoreAsset_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_RestoreAsset_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (*model.Asset, error)) *Repository_RestoreAsset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunInTx provides a mock function with given fields: ctx, fn
+func (_m *Repository) RunInTx(ctx context.Context, fn func(ctx context.Context, repo assets.Repository) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunInTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(ctx context.Context, repo assets.Repository) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_RunInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunInTx'
+type Repository_RunInTx_Call struct {
+	*mock.Call
+}
+
+// RunInTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(ctx context.Context, repo assets.Repository) error
+func (_e *Repository_Expecter) RunInTx(ctx interface{}, fn interface{}) *Repository_RunInTx_Call {
+	return &Repository_RunInTx_Call{Call: _e.mock.On("RunInTx", ctx, fn)}
+}
+
+func (_c *Repository_RunInTx_Call) Run(run func(ctx context.Context, fn func(ctx context.Context, repo assets.Repository) error)) *Repository_RunInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(ctx context.Context, repo assets.Repository) error))
+	})
+	return _c
+}
+
+func (_c *Repository_RunInTx_Call) Return(_a0 error) *Repository_RunInTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_RunInTx_Call) RunAndReturn(run func(context.Context, func(ctx context.Context, repo assets.Repository) error) error) *Repository_RunInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransferAsset provides a mock function with given fields: ctx, id, from, to
+func (_m *Repository) TransferAsset(ctx context.Context, id string, from string, to string) error {
+	ret := _m.Called(ctx, id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferAsset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, id, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_TransferAsset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferAsset'
+type Repository_TransferAsset_Call struct {
+	*mock.Call
+}
+
+// TransferAsset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - from string
+//   - to string
+func (_e *Repository_Expecter) TransferAsset(ctx interface{}, id interface{}, from interface{}, to interface{}) *Repository_TransferAsset_Call {
+	return &Repository_TransferAsset_Call{Call: _e.mock.On("TransferAsset", ctx, id, from, to)}
+}
+
+func (_c *Repository_TransferAsset_Call) Run(run func(ctx context.Context, id string, from string, to string)) *Repository_TransferAsset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_TransferAsset_Call) Return(_a0 error) *Repository_TransferAsset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_TransferAsset_Call) RunAndReturn(run func(context.Context, string, string, string) error) *Repository_TransferAsset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAsset provides a mock function with given fields: ctx, asset, version
+func (_m *Repository) UpdateAsset(ctx context.Context, asset *model.Asset, version *int) error {
+	ret := _m.Called(ctx, asset, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAsset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Asset, *int) error); ok {
+		r0 = rf(ctx, asset, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateAsset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAsset'
+type Repository_UpdateAsset_Call struct {
+	*mock.Call
+}
+
+// UpdateAsset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - asset *model.Asset
+//   - version *int
+func (_e *Repository_Expecter) UpdateAsset(ctx interface{}, asset interface{}, version interface{}) *Repository_UpdateAsset_Call {
+	return &Repository_UpdateAsset_Call{Call: _e.mock.On("UpdateAsset", ctx, asset, version)}
+}
+
+func (_c *Repository_UpdateAsset_Call) Run(run func(ctx context.Context, asset *model.Asset, version *int)) *Repository_UpdateAsset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Asset), args[2].(*int))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateAsset_Call) Return(_a0 error) *Repository_UpdateAsset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_UpdateAsset_Call) RunAndReturn(run func(context.Context, *model.Asset, *int) error) *Repository_UpdateAsset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTransfer provides a mock function with given fields: ctx, transfer
+func (_m *Repository) UpdateTransfer(ctx context.Context, transfer *model.AssetTransfer) error {
+	ret := _m.Called(ctx, transfer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTransfer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AssetTransfer) error); ok {
+		r0 = rf(ctx, transfer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpdateTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTransfer'
+type Repository_UpdateTransfer_Call struct {
+	*mock.Call
+}
+
+// UpdateTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transfer *model.AssetTransfer
+func (_e *Repository_Expecter) UpdateTransfer(ctx interface{}, transfer interface{}) *Repository_UpdateTransfer_Call {
+	return &Repository_UpdateTransfer_Call{Call: _e.mock.On("UpdateTransfer", ctx, transfer)}
+}
+
+func (_c *Repository_UpdateTransfer_Call) Run(run func(ctx context.Context, transfer *model.AssetTransfer)) *Repository_UpdateTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.AssetTransfer))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateTransfer_Call) Return(_a0 error) *Repository_UpdateTransfer_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Repository_UpdateAsset_Call) RunAndReturn(run func(context.Context, *model.Asset) error) *Repository_UpdateAsset_Call {
+func (_c *Repository_UpdateTransfer_Call) RunAndReturn(run func(context.Context, *model.AssetTransfer) error) *Repository_UpdateTransfer_Call {
 	_c.Call.Return(run)
 	return _c
 }
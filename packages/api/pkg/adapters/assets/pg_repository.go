@@ -4,19 +4,30 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/AssetPortal/assets-api/pkg/model"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/schema"
 )
 
 type AssetsRepository struct {
-	db *bun.DB
+	db bun.IDB
 }
 
 func NewAssetsRepository(db *bun.DB) *AssetsRepository {
 	return &AssetsRepository{db: db}
 }
 
+// RunInTx runs fn with a repository whose queries are in one transaction, committed if fn
+// returns nil and rolled back otherwise. Nested calls use savepoints.
+func (repo *AssetsRepository) RunInTx(ctx context.Context, fn func(ctx context.Context, repo Repository) error) error {
+	return repo.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return fn(ctx, &AssetsRepository{db: tx})
+	})
+}
+
 func (repo *AssetsRepository) CreateAsset(ctx context.Context, asset *model.Asset) (*model.Asset, error) {
 	_, err := repo.db.NewInsert().Model(asset).Exec(ctx)
 	if err != nil {
@@ -38,28 +49,160 @@ func (repo *AssetsRepository) GetAssetByID(ctx context.Context, id string) (*mod
 
 	return &dbAsset, nil
 }
-func (repo *AssetsRepository) GetAssets(ctx context.Context, filters *model.GetAssetsInput) ([]*model.Asset, error) {
-	var dbAssets []*model.Asset
-	query := repo.db.NewSelect().Model(&dbAssets)
 
-	if filters.Address != nil {
-		query = query.Where("address = ?", *filters.Address)
+// searchQuery matches the stemmed words of the descriptions, and the ids as they are.
+func searchQuery(q string) schema.QueryWithArgs {
+	return bun.SafeQuery("(websearch_to_tsquery('english', ?0) || websearch_to_tsquery('simple', ?0))", q)
+}
+
+// headlineOptions wraps the matches of the highlighted descriptions in <mark> tags.
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5"
+
+// selectColumns returns the columns of the projection, and of the order to build cursors.
+func selectColumns(filters *model.GetAssetsInput) ([]string, error) {
+	var columns []string
+	seen := map[string]bool{}
+	for _, field := range filters.Projection {
+		column, ok := model.ASSET_COLUMNS[field]
+		if !ok {
+			return nil, fmt.Errorf("cannot select asset field '%s'", field)
+		}
+		seen[column] = true
+		columns = append(columns, column)
+	}
+	for _, key := range filters.Sort {
+		if column := model.ASSET_ORDER_COLUMNS[key.Field]; column != "" && !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	return columns, nil
+}
+
+// orderColumns returns the quoted columns of the keys, which must be in the order whitelist.
+func orderColumns(keys []model.OrderKey) ([]bun.Ident, error) {
+	columns := make([]bun.Ident, len(keys))
+	for i, key := range keys {
+		column, ok := model.ASSET_ORDER_COLUMNS[key.Field]
+		if !ok {
+			return nil, fmt.Errorf("cannot order assets by '%s'", key.Field)
+		}
+		columns[i] = bun.Ident(column)
+	}
+	return columns, nil
+}
+
+// afterCursor matches the assets after the values in the order of the keys:
+// k1 > v1 OR (k1 = v1 AND k2 > v2) OR ..., with < for the descending keys.
+func afterCursor(query *bun.SelectQuery, keys []model.OrderKey, columns []bun.Ident, values []string) *bun.SelectQuery {
+	for i := range keys {
+		query = query.WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			for j := 0; j < i; j++ {
+				q = q.Where("? = ?", columns[j], values[j])
+			}
+			if keys[i].Ascending {
+				return q.Where("? > ?", columns[i], values[i])
+			}
+			return q.Where("? < ?", columns[i], values[i])
+		})
+	}
+	return query
+}
+
+// filterAssets applies the filters shared by GetAssets and CountAssets.
+func filterAssets(query *bun.SelectQuery, filters *model.GetAssetsInput) *bun.SelectQuery {
+	if len(filters.Address) > 0 {
+		query = query.Where("address IN (?)", bun.In(filters.Address))
 	}
 
 	if filters.ID != nil {
 		query = query.Where("id = ?", *filters.ID)
 	}
 
-	if filters.Blockchain != nil {
-		query = query.Where("blockchain = ?", *filters.Blockchain)
+	// Base58 ids cannot contain LIKE wildcards.
+	if filters.IDPrefix != nil {
+		query = query.Where("id LIKE ?", *filters.IDPrefix+"%")
 	}
 
-	if filters.Order.Order != nil {
-		orderClause := fmt.Sprintf("'%s' '%s'", *filters.Order.Order, "ASC")
-		if filters.Order.Ascending != nil && !*filters.Order.Ascending {
-			orderClause = fmt.Sprintf("'%s' '%s'", *filters.Order.Order, "DESC")
+	if len(filters.Blockchain) > 0 {
+		query = query.Where("blockchain IN (?)", bun.In(filters.Blockchain))
+	}
+
+	if filters.CreatedAfter != nil {
+		query = query.Where("created_at > ?", *filters.CreatedAfter)
+	}
+
+	if filters.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filters.CreatedBefore)
+	}
+
+	if filters.UpdatedSince != nil {
+		query = query.Where("updated_at >= ?", *filters.UpdatedSince)
+	}
+
+	if filters.HasImage != nil {
+		if *filters.HasImage {
+			query = query.Where("coalesce(image, '') <> ''")
+		} else {
+			query = query.Where("coalesce(image, '') = ''")
 		}
-		query = query.Order(orderClause)
+	}
+
+	for _, network := range filters.Social {
+		query = query.Where("coalesce(social ->> ?, '') <> ''", network)
+	}
+
+	if filters.Q != nil {
+		query = query.Where("search @@ ?", searchQuery(*filters.Q))
+	}
+
+	if filters.Deleted {
+		query = query.WhereAllWithDeleted()
+	}
+	return query
+}
+
+func (repo *AssetsRepository) GetAssets(ctx context.Context, filters *model.GetAssetsInput) ([]*model.Asset, error) {
+	var dbAssets []*model.Asset
+	query := filterAssets(repo.db.NewSelect().Model(&dbAssets), filters)
+
+	if len(filters.Projection) > 0 {
+		columns, err := selectColumns(filters)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Column(columns...)
+	} else if filters.Q != nil {
+		query = query.ColumnExpr("?TableColumns")
+	}
+
+	if filters.Q != nil {
+		tsquery := searchQuery(*filters.Q)
+		query = query.ColumnExpr("ts_rank(search, ?) AS rank", tsquery)
+		if filters.Highlight != nil && *filters.Highlight {
+			query = query.ColumnExpr("ts_headline('english', coalesce(description, ''), ?, ?) AS highlight", tsquery, headlineOptions)
+		}
+		if filters.IsRanked() {
+			query = query.OrderExpr("rank DESC")
+		}
+	}
+
+	columns, err := orderColumns(filters.Sort)
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range filters.Sort {
+		if key.Ascending {
+			query = query.OrderExpr("? ASC", columns[i])
+		} else {
+			query = query.OrderExpr("? DESC", columns[i])
+		}
+	}
+
+	if filters.After != nil {
+		query = query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return afterCursor(q, filters.Sort, columns, filters.After.Values)
+		})
 	}
 
 	if filters.Pagination.Limit != nil {
@@ -69,7 +212,7 @@ func (repo *AssetsRepository) GetAssets(ctx context.Context, filters *model.GetA
 		query = query.Offset(*filters.Pagination.Offset)
 	}
 
-	err := query.Scan(ctx)
+	err = query.Scan(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query assets: '%s'", err)
 	}
@@ -77,8 +220,43 @@ func (repo *AssetsRepository) GetAssets(ctx context.Context, filters *model.GetA
 	return dbAssets, nil
 }
 
-func (repo *AssetsRepository) UpdateAsset(ctx context.Context, asset *model.Asset) error {
-	res, err := repo.db.NewUpdate().Model(asset).Where("id = ? AND address = ?", asset.ID, asset.Address).OmitZero().Exec(ctx)
+// GetAssetsByIDs returns the assets found for the ids. With fields, only their columns and the id are read.
+func (repo *AssetsRepository) GetAssetsByIDs(ctx context.Context, ids []string, fields []string) ([]*model.Asset, error) {
+	var dbAssets []*model.Asset
+	query := repo.db.NewSelect().Model(&dbAssets).Where("id = ANY(?)", pgdialect.Array(ids))
+	if len(fields) > 0 {
+		// Ordering by id makes it read along with the fields, to key the assets.
+		columns, err := selectColumns(&model.GetAssetsInput{Projection: fields, Sort: []model.OrderKey{{Field: "id"}}})
+		if err != nil {
+			return nil, err
+		}
+		query = query.Column(columns...)
+	}
+	err := query.Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query assets by ids: '%s'", err)
+	}
+	return dbAssets, nil
+}
+
+// CountAssets returns the number of assets matching the filters, regardless of the pagination.
+func (repo *AssetsRepository) CountAssets(ctx context.Context, filters *model.GetAssetsInput) (int, error) {
+	count, err := filterAssets(repo.db.NewSelect().Model((*model.Asset)(nil)), filters).Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count assets: '%s'", err)
+	}
+	return count, nil
+}
+
+// UpdateAsset updates the fields of the asset and its update time, and increments its version, which
+// is read back into it. With a version, the asset is only updated if it's still at that version.
+func (repo *AssetsRepository) UpdateAsset(ctx context.Context, asset *model.Asset, version *int) error {
+	now := time.Now()
+	asset.UpdatedAt = &now
+	query := repo.db.NewUpdate().Model(asset).Where("id = ? AND address = ?", asset.ID, asset.Address).OmitZero().
+		Value("version", "version + 1").
+		Returning("version")
+	res, err := atVersion(query, version).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to update asset in database: '%s'", err)
 	}
@@ -87,12 +265,45 @@ func (repo *AssetsRepository) UpdateAsset(ctx context.Context, asset *model.Asse
 		return fmt.Errorf("failed to update asset in database: '%s'", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("asset with id '%s' and address '%s' does not exist", asset.ID, asset.Address)
+		return notFoundAtVersion(asset.ID, asset.Address, version)
 	}
 	return nil
 }
-func (repo *AssetsRepository) DeleteAsset(ctx context.Context, id, address string) error {
-	res, err := repo.db.NewDelete().Model(&model.Asset{ID: id, Address: address}).Where("id = ? AND address = ?", id, address).Exec(ctx)
+
+// PatchAsset writes the patchable fields of the asset, nil ones included, and increments its version,
+// which is read back into it. With a version, the asset is only updated if it's still at that version.
+func (repo *AssetsRepository) PatchAsset(ctx context.Context, asset *model.Asset, version *int) error {
+	now := time.Now()
+	asset.UpdatedAt = &now
+	query := repo.db.NewUpdate().Model(asset).
+		Column("blockchain", "description", "image", "social", "updated_at", "version").
+		Value("version", "version + 1").
+		Where("id = ? AND address = ?", asset.ID, asset.Address).
+		Returning("version")
+	res, err := atVersion(query, version).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to patch asset in database: '%s'", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to patch asset in database: '%s'", err)
+	}
+	if rowsAffected == 0 {
+		return notFoundAtVersion(asset.ID, asset.Address, version)
+	}
+	return nil
+}
+
+// DeleteAsset soft deletes the asset, which can be restored until it's purged. With a version,
+// the asset is only deleted if it's still at that version.
+func (repo *AssetsRepository) DeleteAsset(ctx context.Context, id, address string, version *int) error {
+	now := time.Now()
+	query := repo.db.NewUpdate().Model((*model.Asset)(nil)).
+		Set("deleted_at = ?", now).
+		Set("updated_at = ?", now).
+		Set("version = version + 1").
+		Where("id = ? AND address = ?", id, address)
+	res, err := atVersion(query, version).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to delete asset in database: '%s'", err)
 	}
@@ -101,7 +312,220 @@ func (repo *AssetsRepository) DeleteAsset(ctx context.Context, id, address strin
 		return fmt.Errorf("failed to update asset in database: '%s'", err)
 	}
 	if rowsAffected == 0 {
+		return notFoundAtVersion(id, address, version)
+	}
+	return nil
+}
+
+func atVersion(query *bun.UpdateQuery, version *int) *bun.UpdateQuery {
+	if version == nil {
+		return query
+	}
+	return query.Where("version = ?", *version)
+}
+
+func notFoundAtVersion(id, address string, version *int) error {
+	if version == nil {
 		return fmt.Errorf("asset with id '%s' and address '%s' does not exist", id, address)
 	}
+	return fmt.Errorf("asset with id '%s' and address '%s' does not exist or is not at version %d", id, address, *version)
+}
+
+// RestoreAsset undeletes the asset of the address if it was deleted after deletedAfter.
+// It returns nil if there is no such asset.
+func (repo *AssetsRepository) RestoreAsset(ctx context.Context, id, address string, deletedAfter time.Time) (*model.Asset, error) {
+	var dbAsset model.Asset
+	res, err := repo.db.NewUpdate().Model(&dbAsset).
+		Set("deleted_at = NULL").
+		Set("updated_at = ?", time.Now()).
+		Set("version = version + 1").
+		Where("id = ? AND address = ?", id, address).
+		Where("deleted_at > ?", deletedAfter).
+		WhereDeleted().
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore asset in database: '%s'", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore asset in database: '%s'", err)
+	}
+	if rowsAffected == 0 {
+		return nil, nil
+	}
+	return &dbAsset, nil
+}
+
+// PurgeDeletedAssets hard deletes up to limit assets deleted before deletedBefore, and their revisions
+// and transfers in the same statement, so that an asset created again with the id doesn't inherit them.
+// It returns the number of deleted assets.
+func (repo *AssetsRepository) PurgeDeletedAssets(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	var deleted int64
+	err := repo.db.NewRaw(`
+		WITH purged AS (
+			DELETE FROM assets
+			WHERE _id IN (SELECT _id FROM assets WHERE deleted_at IS NOT NULL AND deleted_at < ? LIMIT ?)
+			RETURNING id
+		), revisions AS (
+			DELETE FROM asset_revisions WHERE asset_id IN (SELECT id FROM purged)
+		), transfers AS (
+			DELETE FROM asset_transfers WHERE asset_id IN (SELECT id FROM purged)
+		)
+		SELECT count(*) FROM purged`,
+		deletedBefore, limit,
+	).Scan(ctx, &deleted)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted assets: %v", err)
+	}
+	return deleted, nil
+}
+
+// CreateRevision stores the revision with a snapshot of the asset as it is in the database,
+// deleted or not, and the next revision number of the asset.
+func (repo *AssetsRepository) CreateRevision(ctx context.Context, revision *model.AssetRevision) error {
+	err := repo.db.NewRaw(`
+		INSERT INTO asset_revisions (asset_id, revision, operation, address, auth_method, nonce, snapshot)
+		SELECT a.id,
+			coalesce((SELECT max(revision) FROM asset_revisions WHERE asset_id = a.id), 0) + 1,
+			?, ?, ?, ?,
+			to_jsonb(a) - 'search'
+		FROM assets AS a
+		WHERE a.id = ?
+		RETURNING id, revision, snapshot, created_at`,
+		revision.Operation, revision.Address, revision.AuthMethod, revision.Nonce, revision.AssetID,
+	).Scan(ctx, revision)
+	if err != nil {
+		return fmt.Errorf("failed to store asset revision in database: '%s'", err)
+	}
+	return nil
+}
+
+// GetRevisions returns a page of the revisions of the asset, the latest first.
+func (repo *AssetsRepository) GetRevisions(ctx context.Context, assetID string, limit, offset int) ([]*model.AssetRevision, error) {
+	revisions := []*model.AssetRevision{}
+	err := repo.db.NewSelect().Model(&revisions).
+		Where("asset_id = ?", assetID).
+		Order("revision DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query asset revisions: '%s'", err)
+	}
+	return revisions, nil
+}
+
+// GetRevision returns the revision of the asset, or nil if it does not exist.
+func (repo *AssetsRepository) GetRevision(ctx context.Context, assetID string, revision int) (*model.AssetRevision, error) {
+	return repo.getRevision(ctx, repo.db.NewSelect().Where("asset_id = ? AND revision = ?", assetID, revision))
+}
+
+// GetRevisionAt returns the last revision of the asset made at or before the time, or nil if there is none.
+func (repo *AssetsRepository) GetRevisionAt(ctx context.Context, assetID string, at time.Time) (*model.AssetRevision, error) {
+	return repo.getRevision(ctx, repo.db.NewSelect().
+		Where("asset_id = ? AND created_at <= ?", assetID, at).
+		Order("revision DESC").
+		Limit(1))
+}
+
+func (repo *AssetsRepository) getRevision(ctx context.Context, query *bun.SelectQuery) (*model.AssetRevision, error) {
+	var dbRevision model.AssetRevision
+	err := query.Model(&dbRevision).Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query asset revision: '%s'", err)
+	}
+	return &dbRevision, nil
+}
+
+// TransferAsset changes the owner of the asset if it's still owned by from.
+func (repo *AssetsRepository) TransferAsset(ctx context.Context, id, from, to string) error {
+	res, err := repo.db.NewUpdate().Model((*model.Asset)(nil)).
+		Set("address = ?", to).
+		Set("updated_at = ?", time.Now()).
+		Set("version = version + 1").
+		Where("id = ? AND address = ?", id, from).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to transfer asset in database: '%s'", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to transfer asset in database: '%s'", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("asset with id '%s' and address '%s' does not exist", id, from)
+	}
+	return nil
+}
+
+func (repo *AssetsRepository) CreateTransfer(ctx context.Context, transfer *model.AssetTransfer) error {
+	_, err := repo.db.NewInsert().Model(transfer).Returning("id").Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to store asset transfer in database: '%s'", err)
+	}
 	return nil
 }
+
+// GetPendingTransfer returns the unexpired offer of the asset, locked until the end of the
+// transaction, or nil if there is none.
+func (repo *AssetsRepository) GetPendingTransfer(ctx context.Context, assetID string) (*model.AssetTransfer, error) {
+	var dbTransfer model.AssetTransfer
+	err := repo.db.NewSelect().Model(&dbTransfer).
+		Where("asset_id = ? AND status = ?", assetID, model.TRANSFER_PENDING).
+		Where("expires_at > ?", time.Now()).
+		For("UPDATE").
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query asset transfer: '%s'", err)
+	}
+	return &dbTransfer, nil
+}
+
+// UpdateTransfer stores the status of the transfer once it's accepted or cancelled.
+func (repo *AssetsRepository) UpdateTransfer(ctx context.Context, transfer *model.AssetTransfer) error {
+	_, err := repo.db.NewUpdate().Model(transfer).
+		Column("status", "accept_nonce", "cancelled_by", "completed_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update asset transfer in database: '%s'", err)
+	}
+	return nil
+}
+
+// CancelPendingTransfers cancels the pending offers of the asset on behalf of the address,
+// expired or not, so that a new one can be made.
+func (repo *AssetsRepository) CancelPendingTransfers(ctx context.Context, assetID, address string, at time.Time) error {
+	_, err := repo.db.NewUpdate().Model((*model.AssetTransfer)(nil)).
+		Set("status = ?", model.TRANSFER_CANCELLED).
+		Set("cancelled_by = ?", address).
+		Set("completed_at = ?", at).
+		Where("asset_id = ? AND status = ?", assetID, model.TRANSFER_PENDING).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to cancel asset transfers in database: '%s'", err)
+	}
+	return nil
+}
+
+// GetTransfers returns a page of the transfers of the asset, the latest first.
+func (repo *AssetsRepository) GetTransfers(ctx context.Context, assetID string, limit, offset int) ([]*model.AssetTransfer, error) {
+	transfers := []*model.AssetTransfer{}
+	err := repo.db.NewSelect().Model(&transfers).
+		Where("asset_id = ?", assetID).
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query asset transfers: '%s'", err)
+	}
+	return transfers, nil
+}
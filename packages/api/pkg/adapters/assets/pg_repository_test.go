@@ -0,0 +1,345 @@
+package assets_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AssetPortal/assets-api/pkg/adapters/assets"
+	"github.com/AssetPortal/assets-api/pkg/adapters/pgtest"
+	"github.com/AssetPortal/assets-api/pkg/model"
+)
+
+// These tests run against the PostgreSQL database of TEST_DATABASE_URL, with the schema of the
+// migrations. They are skipped when it's not set.
+const testAddress = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+
+func newTestRepository(t *testing.T) *assets.AssetsRepository {
+	db := pgtest.NewDB(t, "assets")
+	ctx := context.Background()
+	repo := assets.NewAssetsRepository(db)
+	base := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	fixtures := []struct {
+		id         string
+		blockchain string
+		hours      int
+	}{
+		{"a1", model.POLKADOT, 2},
+		{"b2", model.KUSAMA, 0},
+		{"c3", model.POLKADOT, 1},
+		{"d4", model.KUSAMA, 1},
+		{"e5", model.POLKADOT, 0},
+	}
+	for _, fixture := range fixtures {
+		blockchain := fixture.blockchain
+		createdAt := base.Add(time.Duration(fixture.hours) * time.Hour)
+		_, err := repo.CreateAsset(ctx, &model.Asset{ID: fixture.id, Address: testAddress, Blockchain: &blockchain, CreatedAt: &createdAt})
+		require.NoError(t, err)
+	}
+	return repo
+}
+
+func getAssetIDs(t *testing.T, repo *assets.AssetsRepository, filters *model.GetAssetsInput) []string {
+	require.NoError(t, filters.Validate())
+	result, err := repo.GetAssets(context.Background(), filters)
+	require.NoError(t, err)
+	ids := make([]string, len(result))
+	for i, asset := range result {
+		ids[i] = asset.ID
+	}
+	return ids
+}
+
+func TestAssetsRepository_GetAssets_Order(t *testing.T) {
+	repo := newTestRepository(t)
+	order := func(order string) *model.GetAssetsInput {
+		return &model.GetAssetsInput{Order: model.Order{Order: &order}}
+	}
+
+	assert.Equal(t, []string{"a1", "b2", "c3", "d4", "e5"}, getAssetIDs(t, repo, &model.GetAssetsInput{}))
+	assert.Equal(t, []string{"e5", "d4", "c3", "b2", "a1"}, getAssetIDs(t, repo, order("id:desc")))
+	assert.Equal(t, []string{"b2", "e5", "c3", "d4", "a1"}, getAssetIDs(t, repo, order("created_at:asc")))
+	assert.Equal(t, []string{"a1", "d4", "c3", "e5", "b2"}, getAssetIDs(t, repo, order("created_at:desc")))
+	assert.Equal(t, []string{"d4", "b2", "a1", "c3", "e5"}, getAssetIDs(t, repo, order("blockchain:asc,created_at:desc")))
+	assert.Equal(t, []string{"a1", "c3", "e5", "b2", "d4"}, getAssetIDs(t, repo, order("blockchain:desc,id:asc")))
+}
+
+func TestAssetsRepository_GetAssets_Cursor(t *testing.T) {
+	repo := newTestRepository(t)
+	order := "blockchain:asc,created_at:desc"
+	limit := 2
+
+	var ids []string
+	filters := &model.GetAssetsInput{Order: model.Order{Order: &order}, Pagination: model.Pagination{Limit: &limit}}
+	for page := 0; page < 5; page++ {
+		require.NoError(t, filters.Validate())
+		result, err := repo.GetAssets(context.Background(), filters)
+		require.NoError(t, err)
+		for _, asset := range result {
+			ids = append(ids, asset.ID)
+		}
+		if len(result) < limit {
+			break
+		}
+		cursor := model.NewCursor(result[len(result)-1], filters.Sort).Encode()
+		filters = &model.GetAssetsInput{Order: model.Order{Order: &order}, Pagination: model.Pagination{Limit: &limit, Cursor: &cursor}}
+	}
+
+	assert.Equal(t, []string{"d4", "b2", "a1", "c3", "e5"}, ids)
+}
+
+func TestAssetsRepository_GetAssets_Projection(t *testing.T) {
+	repo := newTestRepository(t)
+	fields := "id,blockchain"
+	order := "created_at:desc"
+	filters := &model.GetAssetsInput{Fields: model.Fields{Fields: &fields}, Order: model.Order{Order: &order}}
+	require.NoError(t, filters.Validate())
+
+	result, err := repo.GetAssets(context.Background(), filters)
+	require.NoError(t, err)
+	require.Len(t, result, 5)
+	for _, asset := range result {
+		assert.NotEmpty(t, asset.ID)
+		assert.NotNil(t, asset.Blockchain)
+		assert.Empty(t, asset.Address)
+		// The order columns are read to build cursors.
+		assert.NotNil(t, asset.CreatedAt)
+		assert.NotNil(t, asset.ID_)
+	}
+}
+
+func TestAssetsRepository_GetAssetsByIDs(t *testing.T) {
+	repo := newTestRepository(t)
+
+	result, err := repo.GetAssetsByIDs(context.Background(), []string{"a1", "c3", "zz"}, []string{"image"})
+	require.NoError(t, err)
+	ids := []string{}
+	for _, asset := range result {
+		ids = append(ids, asset.ID)
+		assert.Empty(t, asset.Address)
+	}
+	assert.ElementsMatch(t, []string{"a1", "c3"}, ids)
+}
+
+func TestAssetsRepository_SoftDelete(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.DeleteAsset(ctx, "c3", testAddress, nil))
+	asset, err := repo.GetAssetByID(ctx, "c3")
+	require.NoError(t, err)
+	assert.Nil(t, asset)
+	assert.Equal(t, []string{"a1", "b2", "d4", "e5"}, getAssetIDs(t, repo, &model.GetAssetsInput{}))
+	assert.Equal(t, []string{"a1", "b2", "c3", "d4", "e5"}, getAssetIDs(t, repo, &model.GetAssetsInput{Deleted: true}))
+	assert.Error(t, repo.DeleteAsset(ctx, "c3", testAddress, nil))
+
+	// Only the owner can restore it, within the window.
+	restored, err := repo.RestoreAsset(ctx, "c3", "other", time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, restored)
+	restored, err = repo.RestoreAsset(ctx, "c3", testAddress, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, restored)
+	restored, err = repo.RestoreAsset(ctx, "c3", testAddress, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, restored)
+	assert.Nil(t, restored.DeletedAt)
+	assert.Equal(t, []string{"a1", "b2", "c3", "d4", "e5"}, getAssetIDs(t, repo, &model.GetAssetsInput{}))
+}
+
+func TestAssetsRepository_Versions(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	stale, current := 1, 2
+
+	description := "new description"
+	asset := &model.Asset{ID: "a1", Address: testAddress, Description: &description}
+	require.NoError(t, repo.UpdateAsset(ctx, asset, nil))
+	assert.Equal(t, 2, asset.Version)
+	err := repo.UpdateAsset(ctx, &model.Asset{ID: "a1", Address: testAddress, Description: &description}, &stale)
+	assert.ErrorContains(t, err, "is not at version 1")
+
+	assert.Error(t, repo.DeleteAsset(ctx, "a1", testAddress, &stale))
+	require.NoError(t, repo.DeleteAsset(ctx, "a1", testAddress, &current))
+	restored, err := repo.RestoreAsset(ctx, "a1", testAddress, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 4, restored.Version)
+}
+
+func TestAssetsRepository_PatchAsset(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	description := "description"
+	require.NoError(t, repo.UpdateAsset(ctx, &model.Asset{ID: "a1", Address: testAddress, Description: &description}, nil))
+
+	asset, err := repo.GetAssetByID(ctx, "a1")
+	require.NoError(t, err)
+	asset.Description = nil
+	version := asset.Version
+	require.NoError(t, repo.PatchAsset(ctx, asset, &version))
+	assert.Equal(t, version+1, asset.Version)
+	assert.ErrorContains(t, repo.PatchAsset(ctx, asset, &version), "is not at version")
+
+	asset, err = repo.GetAssetByID(ctx, "a1")
+	require.NoError(t, err)
+	assert.Nil(t, asset.Description)
+	assert.Equal(t, model.POLKADOT, *asset.Blockchain)
+	assert.NotNil(t, asset.UpdatedAt)
+}
+
+func TestAssetsRepository_UpdatedSince(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	since := time.Now()
+	filters := &model.GetAssetsInput{UpdatedSince: &since}
+	assert.Empty(t, getAssetIDs(t, repo, filters))
+
+	description := "new description"
+	asset := &model.Asset{ID: "a1", Address: testAddress, Description: &description}
+	require.NoError(t, repo.UpdateAsset(ctx, asset, nil))
+	require.NotNil(t, asset.UpdatedAt)
+	assert.Equal(t, []string{"a1"}, getAssetIDs(t, repo, filters))
+
+	require.NoError(t, repo.DeleteAsset(ctx, "b2", testAddress, nil))
+	assert.Equal(t, []string{"b2"}, getAssetIDs(t, repo, &model.GetAssetsInput{UpdatedSince: &since, Deleted: true}))
+	_, err := repo.RestoreAsset(ctx, "b2", testAddress, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a1", "b2"}, getAssetIDs(t, repo, filters))
+}
+
+func TestAssetsRepository_PurgeDeletedAssets(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	require.NoError(t, repo.DeleteAsset(ctx, "a1", testAddress, nil))
+	require.NoError(t, repo.DeleteAsset(ctx, "b2", testAddress, nil))
+
+	deleted, err := repo.PurgeDeletedAssets(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	deleted, err = repo.PurgeDeletedAssets(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.Equal(t, []string{"c3", "d4", "e5"}, getAssetIDs(t, repo, &model.GetAssetsInput{Deleted: true}))
+}
+
+func TestAssetsRepository_Revisions(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	signer := &model.Principal{Address: testAddress, AuthMethod: model.AUTH_METHOD_SIGNATURE, Nonce: "nonce"}
+
+	first := model.NewAssetRevision("a1", model.REVISION_CREATE, signer)
+	require.NoError(t, repo.CreateRevision(ctx, first))
+	assert.Equal(t, 1, first.Revision)
+	require.NotNil(t, first.Snapshot)
+	assert.Nil(t, first.Snapshot.Description)
+
+	description := "new description"
+	require.NoError(t, repo.UpdateAsset(ctx, &model.Asset{ID: "a1", Address: testAddress, Description: &description}, nil))
+	second := model.NewAssetRevision("a1", model.REVISION_UPDATE, signer)
+	require.NoError(t, repo.CreateRevision(ctx, second))
+	assert.Equal(t, 2, second.Revision)
+	assert.Equal(t, &description, second.Snapshot.Description)
+
+	// Deleted assets are snapshotted too.
+	require.NoError(t, repo.DeleteAsset(ctx, "a1", testAddress, nil))
+	third := model.NewAssetRevision("a1", model.REVISION_DELETE, signer)
+	require.NoError(t, repo.CreateRevision(ctx, third))
+	assert.NotNil(t, third.Snapshot.DeletedAt)
+
+	revisions, err := repo.GetRevisions(ctx, "a1", 2, 0)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 3, revisions[0].Revision)
+	assert.Equal(t, "nonce", *revisions[0].Nonce)
+
+	revision, err := repo.GetRevision(ctx, "a1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, &description, revision.Snapshot.Description)
+	revision, err = repo.GetRevisionAt(ctx, "a1", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 3, revision.Revision)
+	revision, err = repo.GetRevisionAt(ctx, "a1", time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, revision)
+}
+
+func TestAssetsRepository_PurgeDeletedAssets_History(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	signer := &model.Principal{Address: testAddress, AuthMethod: model.AUTH_METHOD_SIGNATURE, Nonce: "nonce"}
+	require.NoError(t, repo.CreateRevision(ctx, model.NewAssetRevision("a1", model.REVISION_CREATE, signer)))
+	require.NoError(t, repo.DeleteAsset(ctx, "a1", testAddress, nil))
+	require.NoError(t, repo.CreateRevision(ctx, model.NewAssetRevision("a1", model.REVISION_DELETE, signer)))
+	require.NoError(t, repo.CreateRevision(ctx, model.NewAssetRevision("b2", model.REVISION_CREATE, signer)))
+	require.NoError(t, repo.CreateTransfer(ctx, model.NewAssetTransfer("a1", "recipient", signer, time.Now(), time.Hour)))
+	require.NoError(t, repo.CreateTransfer(ctx, model.NewAssetTransfer("b2", "recipient", signer, time.Now(), time.Hour)))
+
+	deleted, err := repo.PurgeDeletedAssets(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	// The asset created again with the id starts a new history.
+	polkadot := model.POLKADOT
+	_, err = repo.CreateAsset(ctx, &model.Asset{ID: "a1", Address: testAddress, Blockchain: &polkadot})
+	require.NoError(t, err)
+	revision := model.NewAssetRevision("a1", model.REVISION_CREATE, signer)
+	require.NoError(t, repo.CreateRevision(ctx, revision))
+	assert.Equal(t, 1, revision.Revision)
+	revisions, err := repo.GetRevisions(ctx, "a1", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, revision.ID, revisions[0].ID)
+	transfers, err := repo.GetTransfers(ctx, "a1", 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+
+	// The history of the other assets is kept.
+	revisions, err = repo.GetRevisions(ctx, "b2", 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, revisions, 1)
+	transfers, err = repo.GetTransfers(ctx, "b2", 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, transfers, 1)
+}
+
+func TestAssetsRepository_Transfers(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	recipient := "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"
+	owner := &model.Principal{Address: testAddress, AuthMethod: model.AUTH_METHOD_SIGNATURE, Nonce: "nonce"}
+
+	expired := model.NewAssetTransfer("a1", recipient, owner, time.Now().Add(-2*time.Hour), time.Hour)
+	require.NoError(t, repo.CreateTransfer(ctx, expired))
+	require.NotNil(t, expired.ID)
+	transfer, err := repo.GetPendingTransfer(ctx, "a1")
+	require.NoError(t, err)
+	assert.Nil(t, transfer)
+
+	// A new offer cancels the previous one, even if it expired.
+	require.NoError(t, repo.CancelPendingTransfers(ctx, "a1", testAddress, time.Now()))
+	require.NoError(t, repo.CreateTransfer(ctx, model.NewAssetTransfer("a1", recipient, owner, time.Now(), time.Hour)))
+	transfer, err = repo.GetPendingTransfer(ctx, "a1")
+	require.NoError(t, err)
+	require.NotNil(t, transfer)
+	assert.Equal(t, recipient, transfer.To)
+
+	require.NoError(t, repo.TransferAsset(ctx, "a1", testAddress, recipient))
+	assert.Error(t, repo.TransferAsset(ctx, "a1", testAddress, recipient))
+	transfer.Accept(&model.Principal{Address: recipient, Nonce: "accept"}, time.Now())
+	require.NoError(t, repo.UpdateTransfer(ctx, transfer))
+	asset, err := repo.GetAssetByID(ctx, "a1")
+	require.NoError(t, err)
+	assert.Equal(t, recipient, asset.Address)
+
+	transfers, err := repo.GetTransfers(ctx, "a1", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, transfers, 2)
+	assert.Equal(t, model.TRANSFER_ACCEPTED, transfers[0].Status)
+	assert.Equal(t, "accept", *transfers[0].AcceptNonce)
+	assert.Equal(t, model.TRANSFER_CANCELLED, transfers[1].Status)
+	assert.Equal(t, testAddress, *transfers[1].CancelledBy)
+}
@@ -2,6 +2,7 @@ package assets
 
 import (
 	"context"
+	"time"
 
 	"github.com/AssetPortal/assets-api/pkg/model"
 )
@@ -10,6 +11,22 @@ type Repository interface {
 	CreateAsset(ctx context.Context, asset *model.Asset) (*model.Asset, error)
 	GetAssetByID(ctx context.Context, id string) (*model.Asset, error)
 	GetAssets(ctx context.Context, filters *model.GetAssetsInput) ([]*model.Asset, error)
-	UpdateAsset(ctx context.Context, asset *model.Asset) error
-	DeleteAsset(ctx context.Context, id, address string) error
+	CountAssets(ctx context.Context, filters *model.GetAssetsInput) (int, error)
+	GetAssetsByIDs(ctx context.Context, ids []string, fields []string) ([]*model.Asset, error)
+	UpdateAsset(ctx context.Context, asset *model.Asset, version *int) error
+	PatchAsset(ctx context.Context, asset *model.Asset, version *int) error
+	DeleteAsset(ctx context.Context, id, address string, version *int) error
+	RestoreAsset(ctx context.Context, id, address string, deletedAfter time.Time) (*model.Asset, error)
+	PurgeDeletedAssets(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+	CreateRevision(ctx context.Context, revision *model.AssetRevision) error
+	GetRevisions(ctx context.Context, assetID string, limit, offset int) ([]*model.AssetRevision, error)
+	GetRevision(ctx context.Context, assetID string, revision int) (*model.AssetRevision, error)
+	GetRevisionAt(ctx context.Context, assetID string, at time.Time) (*model.AssetRevision, error)
+	TransferAsset(ctx context.Context, id, from, to string) error
+	CreateTransfer(ctx context.Context, transfer *model.AssetTransfer) error
+	GetPendingTransfer(ctx context.Context, assetID string) (*model.AssetTransfer, error)
+	UpdateTransfer(ctx context.Context, transfer *model.AssetTransfer) error
+	CancelPendingTransfers(ctx context.Context, assetID, address string, at time.Time) error
+	GetTransfers(ctx context.Context, assetID string, limit, offset int) ([]*model.AssetTransfer, error)
+	RunInTx(ctx context.Context, fn func(ctx context.Context, repo Repository) error) error
 }
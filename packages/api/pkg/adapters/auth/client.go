@@ -6,6 +6,10 @@ import (
 	"github.com/AssetPortal/assets-api/pkg/model"
 )
 
+// Signature verification providers
+const PROVIDER_NATIVE = "native"
+const PROVIDER_HTTP = "http"
+
 type Client interface {
 	VerifySignature(ctx context.Context, message, address, signature string) (*model.Auth, error)
 }
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/ChainSafe/go-schnorrkel"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/blake2b"
+)
+
+// substrateContext is the signing context used by Substrate for sr25519 signatures.
+var substrateContext = []byte("substrate")
+
+const (
+	signatureLength      = 64
+	ecdsaSignatureLength = 65
+)
+
+// Key types of the MultiSignature encoding.
+const (
+	keyTypeEd25519 byte = 0x00
+	keyTypeSr25519 byte = 0x01
+	keyTypeEcdsa   byte = 0x02
+)
+
+// NativeClient verifies Polkadot signatures in process.
+// It accepts sr25519, ed25519 and ecdsa signatures over the raw message or
+// over the message wrapped in <Bytes>...</Bytes>, as polkadot.js signRaw does.
+type NativeClient struct{}
+
+func NewNativeClient() *NativeClient {
+	return &NativeClient{}
+}
+
+func (c *NativeClient) VerifySignature(ctx context.Context, message, address, signature string) (*model.Auth, error) {
+	_, publicKey, err := model.DecodeAddress(address)
+	if err != nil {
+		return &model.Auth{OK: false, Message: err.Error()}, nil
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err != nil {
+		return &model.Auth{OK: false, Message: "signature is not valid hex"}, nil
+	}
+
+	for _, msg := range candidateMessages(message) {
+		if keyType, ok := verify(msg, publicKey, sig); ok {
+			return &model.Auth{OK: true, Message: "valid signature", KeyType: keyType}, nil
+		}
+	}
+	return &model.Auth{OK: false, Message: "invalid signature"}, nil
+}
+
+// candidateMessages returns the payloads the signature may have been made over.
+// Like polkadot.js, a 0x-prefixed hex message is signed as the bytes it encodes.
+func candidateMessages(message string) [][]byte {
+	raw := []byte(message)
+	if strings.HasPrefix(message, "0x") {
+		if decoded, err := hex.DecodeString(message[2:]); err == nil {
+			raw = decoded
+		}
+	}
+	wrapped := make([]byte, 0, len(raw)+len("<Bytes></Bytes>"))
+	wrapped = append(wrapped, "<Bytes>"...)
+	wrapped = append(wrapped, raw...)
+	wrapped = append(wrapped, "</Bytes>"...)
+	return [][]byte{wrapped, raw}
+}
+
+// verify returns the key type of the signature if it is valid.
+func verify(message, publicKey, signature []byte) (string, bool) {
+	switch len(signature) {
+	case signatureLength:
+		if verifySr25519(message, publicKey, signature) {
+			return model.KEY_TYPE_SR25519, true
+		}
+		if verifyEd25519(message, publicKey, signature) {
+			return model.KEY_TYPE_ED25519, true
+		}
+	case signatureLength + 1:
+		switch signature[0] {
+		case keyTypeEd25519:
+			if verifyEd25519(message, publicKey, signature[1:]) {
+				return model.KEY_TYPE_ED25519, true
+			}
+		case keyTypeSr25519:
+			if verifySr25519(message, publicKey, signature[1:]) {
+				return model.KEY_TYPE_SR25519, true
+			}
+		}
+		if verifyEcdsa(message, publicKey, signature) {
+			return model.KEY_TYPE_ECDSA, true
+		}
+	case ecdsaSignatureLength + 1:
+		if signature[0] == keyTypeEcdsa && verifyEcdsa(message, publicKey, signature[1:]) {
+			return model.KEY_TYPE_ECDSA, true
+		}
+	}
+	return "", false
+}
+
+func verifySr25519(message, publicKey, signature []byte) bool {
+	var key [schnorrkel.PublicKeySize]byte
+	copy(key[:], publicKey)
+	pub, err := schnorrkel.NewPublicKey(key)
+	if err != nil {
+		return false
+	}
+	var encoded [schnorrkel.SignatureSize]byte
+	copy(encoded[:], signature)
+	sig := &schnorrkel.Signature{}
+	if err := sig.Decode(encoded); err != nil {
+		return false
+	}
+	ok, err := pub.Verify(sig, schnorrkel.NewSigningContext(substrateContext, message))
+	return err == nil && ok
+}
+
+func verifyEd25519(message, publicKey, signature []byte) bool {
+	return ed25519.Verify(ed25519.PublicKey(publicKey), message, signature)
+}
+
+// verifyEcdsa recovers the signer of a Substrate ecdsa signature (r || s || v)
+// and compares the blake2b-256 hash of its compressed public key with the account id.
+func verifyEcdsa(message, accountID, signature []byte) bool {
+	recoveryID := signature[64]
+	if recoveryID >= 27 {
+		recoveryID -= 27
+	}
+	if recoveryID > 3 {
+		return false
+	}
+	// RecoverCompact expects the recovery code first, flagged for a compressed key.
+	compact := make([]byte, 0, ecdsaSignatureLength)
+	compact = append(compact, 27+4+recoveryID)
+	compact = append(compact, signature[:64]...)
+
+	hash := blake2b.Sum256(message)
+	pub, _, err := ecdsa.RecoverCompact(compact, hash[:])
+	if err != nil {
+		return false
+	}
+	signer := blake2b.Sum256(pub.SerializeCompressed())
+	return bytes.Equal(signer[:], accountID)
+}
@@ -0,0 +1,171 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/AssetPortal/assets-api/pkg/adapters/auth"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/ChainSafe/go-schnorrkel"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Well known //Alice development account
+const (
+	aliceMiniSecret       = "e5be9a5092b81bca64be81d212e7f2f9eba183bb7a90954f7b76361f6edb5c0a"
+	alicePublicKey        = "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+	aliceSubstrateAddress = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	alicePolkadotAddress  = "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5"
+)
+
+const nonce = "db1dce7837b0976fe042cda63c8129e4ea396407158cc494c4b1250f368d58a8"
+
+func wrap(message string) []byte {
+	return []byte("<Bytes>" + message + "</Bytes>")
+}
+
+func TestNativeClient_Sr25519(t *testing.T) {
+	miniSecret, err := schnorrkel.NewMiniSecretKeyFromHex("0x" + aliceMiniSecret)
+	require.NoError(t, err)
+	secretKey := miniSecret.ExpandEd25519()
+	publicKey := miniSecret.Public().Encode()
+	assert.Equal(t, alicePublicKey, hex.EncodeToString(publicKey[:]))
+
+	sign := func(message []byte) string {
+		sig, err := secretKey.Sign(schnorrkel.NewSigningContext([]byte("substrate"), message))
+		require.NoError(t, err)
+		encoded := sig.Encode()
+		return "0x" + hex.EncodeToString(encoded[:])
+	}
+
+	client := auth.NewNativeClient()
+
+	res, err := client.VerifySignature(context.Background(), nonce, aliceSubstrateAddress, sign(wrap(nonce)))
+	require.NoError(t, err)
+	assert.True(t, res.OK)
+	assert.Equal(t, model.KEY_TYPE_SR25519, res.KeyType)
+
+	res, err = client.VerifySignature(context.Background(), nonce, alicePolkadotAddress, sign([]byte(nonce)))
+	require.NoError(t, err)
+	assert.True(t, res.OK)
+
+	res, err = client.VerifySignature(context.Background(), "another-nonce", aliceSubstrateAddress, sign(wrap(nonce)))
+	require.NoError(t, err)
+	assert.False(t, res.OK)
+
+	bob := model.EncodeAddress(42, make([]byte, 32))
+	res, err = client.VerifySignature(context.Background(), nonce, bob, sign(wrap(nonce)))
+	require.NoError(t, err)
+	assert.False(t, res.OK)
+}
+
+func TestNativeClient_Ed25519(t *testing.T) {
+	seed, _ := hex.DecodeString("abf8e5bdbe30c65656c0a3cbd181ff8a56294a69dfedd27982aace4a76909115")
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	address := model.EncodeAddress(0, privateKey.Public().(ed25519.PublicKey))
+	signature := ed25519.Sign(privateKey, wrap(nonce))
+
+	client := auth.NewNativeClient()
+
+	res, err := client.VerifySignature(context.Background(), nonce, address, hex.EncodeToString(signature))
+	require.NoError(t, err)
+	assert.True(t, res.OK)
+	assert.Equal(t, model.KEY_TYPE_ED25519, res.KeyType)
+
+	// MultiSignature encoding with the ed25519 type prefix
+	res, err = client.VerifySignature(context.Background(), nonce, address, "0x00"+hex.EncodeToString(signature))
+	require.NoError(t, err)
+	assert.True(t, res.OK)
+
+	res, err = client.VerifySignature(context.Background(), nonce, address, "0x01"+hex.EncodeToString(signature))
+	require.NoError(t, err)
+	assert.False(t, res.OK)
+}
+
+func TestNativeClient_Ecdsa(t *testing.T) {
+	keyBytes, _ := hex.DecodeString("cb6df9de1efca7a3998a8ead4e02159d5fa99c3e0d4fd6432667390bb4726854")
+	privateKey := secp256k1.PrivKeyFromBytes(keyBytes)
+	accountID := blake2b.Sum256(privateKey.PubKey().SerializeCompressed())
+	address := model.EncodeAddress(0, accountID[:])
+
+	hash := blake2b.Sum256(wrap(nonce))
+	compact := ecdsa.SignCompact(privateKey, hash[:], true)
+	// Substrate encodes ecdsa signatures as r || s || recovery id
+	signature := append(append([]byte{}, compact[1:]...), compact[0]-27-4)
+
+	client := auth.NewNativeClient()
+
+	res, err := client.VerifySignature(context.Background(), nonce, address, hex.EncodeToString(signature))
+	require.NoError(t, err)
+	assert.True(t, res.OK)
+	assert.Equal(t, model.KEY_TYPE_ECDSA, res.KeyType)
+
+	res, err = client.VerifySignature(context.Background(), nonce, address, "0x02"+hex.EncodeToString(signature))
+	require.NoError(t, err)
+	assert.True(t, res.OK)
+
+	res, err = client.VerifySignature(context.Background(), "another-nonce", address, hex.EncodeToString(signature))
+	require.NoError(t, err)
+	assert.False(t, res.OK)
+}
+
+// Signatures of "hello world" made by polkadot.js, from the signatureVerify tests of @polkadot/util-crypto.
+// Unlike the signatures above, they are not made by the libraries that verify them.
+func TestNativeClient_PolkadotJSVectors(t *testing.T) {
+	tests := []struct {
+		keyType   string
+		address   string
+		signature string
+	}{
+		{
+			keyType:   model.KEY_TYPE_SR25519,
+			address:   "EK1bFgKm2FsghcttHT7TB7rNyXApFgs9fCbijMGQNyFGBQm",
+			signature: "0xca01419b5a17219f7b78335658cab3b126db523a5df7be4bfc2bef76c2eb3b1dcf4ca86eb877d0a6cf6df12db5995c51d13b00e005d053b892bd09c594434288",
+		},
+		{
+			keyType:   model.KEY_TYPE_ED25519,
+			address:   "DxN4uvzwPzJLtn17yew6jEffPhXQfdKHTp2brufb98vGbPN",
+			signature: "0x299d3bf4c8bb51af732f8067b3a3015c0862a5ff34721749d8ed6577ea2708365d1c5f76bd519009971e41156f12c70abc2533837ceb3bad9a05a99ab923de06",
+		},
+		{
+			keyType:   model.KEY_TYPE_ECDSA,
+			address:   "XyFVXiGaHxoBhXZkSh6NS2rjFyVaVNUo5UiZDqZbuSfUdji",
+			signature: "0x994638ee586d2c5dbd9bacacbc35d9b7e9018de8f7892f00c900db63bc57b1283e2ee7bc51a9b1c1dae121ac4f4b9e2a41cd1d6bf4bb3e24d7fed6faf6d85e0501",
+		},
+	}
+
+	client := auth.NewNativeClient()
+
+	for _, tt := range tests {
+		res, err := client.VerifySignature(context.Background(), "hello world", tt.address, tt.signature)
+		require.NoError(t, err)
+		assert.True(t, res.OK, tt.keyType)
+		assert.Equal(t, tt.keyType, res.KeyType)
+
+		res, err = client.VerifySignature(context.Background(), "hello world!", tt.address, tt.signature)
+		require.NoError(t, err)
+		assert.False(t, res.OK, tt.keyType)
+	}
+}
+
+func TestNativeClient_InvalidInput(t *testing.T) {
+	client := auth.NewNativeClient()
+
+	res, err := client.VerifySignature(context.Background(), nonce, "invalid-address", "0x00")
+	require.NoError(t, err)
+	assert.False(t, res.OK)
+
+	res, err = client.VerifySignature(context.Background(), nonce, aliceSubstrateAddress, "not-hex")
+	require.NoError(t, err)
+	assert.False(t, res.OK)
+
+	res, err = client.VerifySignature(context.Background(), nonce, aliceSubstrateAddress, "0x1234")
+	require.NoError(t, err)
+	assert.False(t, res.OK)
+}
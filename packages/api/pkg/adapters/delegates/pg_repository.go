@@ -0,0 +1,76 @@
+package delegates
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/uptrace/bun"
+)
+
+type DelegatesRepository struct {
+	db *bun.DB
+}
+
+func NewDelegatesRepository(db *bun.DB) *DelegatesRepository {
+	return &DelegatesRepository{db: db}
+}
+
+// SetDelegate adds a delegate to the owner, or changes its role if it already exists.
+func (repo *DelegatesRepository) SetDelegate(ctx context.Context, delegate *model.Delegate) (*model.Delegate, error) {
+	_, err := repo.db.NewInsert().Model(delegate).
+		On("CONFLICT (owner, address) DO UPDATE").
+		Set("role = EXCLUDED.role").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store delegate in database: %v", err)
+	}
+	return delegate, nil
+}
+
+func (repo *DelegatesRepository) GetDelegate(ctx context.Context, owner, address string) (*model.Delegate, error) {
+	var dbDelegate model.Delegate
+	err := repo.db.NewSelect().Model(&dbDelegate).
+		Where("owner = ?", owner).
+		Where("address = ?", address).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query delegate: %v", err)
+	}
+	return &dbDelegate, nil
+}
+
+func (repo *DelegatesRepository) GetDelegates(ctx context.Context, owner string) ([]*model.Delegate, error) {
+	dbDelegates := []*model.Delegate{}
+	err := repo.db.NewSelect().Model(&dbDelegates).
+		Where("owner = ?", owner).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query delegates: %v", err)
+	}
+	return dbDelegates, nil
+}
+
+func (repo *DelegatesRepository) DeleteDelegate(ctx context.Context, owner, address string) error {
+	res, err := repo.db.NewDelete().Model((*model.Delegate)(nil)).
+		Where("owner = ?", owner).
+		Where("address = ?", address).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete delegate in database: %v", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete delegate in database: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("delegate '%s' of owner '%s' does not exist", address, owner)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package delegates
+
+import (
+	"context"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+)
+
+type Repository interface {
+	SetDelegate(ctx context.Context, delegate *model.Delegate) (*model.Delegate, error)
+	GetDelegate(ctx context.Context, owner, address string) (*model.Delegate, error)
+	GetDelegates(ctx context.Context, owner string) ([]*model.Delegate, error)
+	DeleteDelegate(ctx context.Context, owner, address string) error
+}
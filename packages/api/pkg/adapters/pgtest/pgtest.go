@@ -0,0 +1,81 @@
+// Package pgtest sets up the PostgreSQL database of the repository tests.
+package pgtest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	// The driver of the test database.
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+// NewDB returns a database with the schema of the migrations of packages/migrate, in a new PostgreSQL
+// schema of the database of TEST_DATABASE_URL that is dropped after the test. All its connections use
+// the new schema, so that the tests can run concurrent statements. The test is skipped when
+// TEST_DATABASE_URL is not set.
+func NewDB(t *testing.T, name string) *bun.DB {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+	schema := fmt.Sprintf("test_%s_%d", name, time.Now().UnixNano())
+	adminDB, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	_, err = adminDB.ExecContext(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+
+	sqlDB, err := sql.Open("postgres", withSearchPath(t, dsn, schema))
+	require.NoError(t, err)
+	db := bun.NewDB(sqlDB, pgdialect.New())
+	t.Cleanup(func() {
+		_ = db.Close()
+		_, _ = adminDB.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE")
+		_ = adminDB.Close()
+	})
+	for _, migration := range migrations(t) {
+		query, err := os.ReadFile(migration)
+		require.NoError(t, err)
+		_, err = db.ExecContext(ctx, string(query))
+		require.NoError(t, err, "error applying %s", filepath.Base(migration))
+	}
+	return db
+}
+
+// migrations returns the up migrations of packages/migrate, in the order they are applied.
+func migrations(t *testing.T) []string {
+	_, file, _, ok := runtime.Caller(0)
+	require.True(t, ok)
+	dir := filepath.Join(filepath.Dir(file), "..", "..", "..", "..", "migrate", "migrations")
+	files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files, "no migrations in %s", dir)
+	sort.Strings(files)
+	return files
+}
+
+// withSearchPath adds the schema as the search path of the connections of the data source name,
+// which is a URL or a list of key=value settings.
+func withSearchPath(t *testing.T, dsn, schema string) string {
+	if !strings.Contains(dsn, "://") {
+		return dsn + " search_path=" + schema
+	}
+	u, err := url.Parse(dsn)
+	require.NoError(t, err)
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,87 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/uptrace/bun"
+)
+
+type SessionsRepository struct {
+	db *bun.DB
+}
+
+func NewSessionsRepository(db *bun.DB) *SessionsRepository {
+	return &SessionsRepository{db: db}
+}
+
+func (repo *SessionsRepository) CreateSession(ctx context.Context, session *model.Session) (*model.Session, error) {
+	_, err := repo.db.NewInsert().Model(session).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store session in database: %v", err)
+	}
+	return session, nil
+}
+
+func (repo *SessionsRepository) GetSession(ctx context.Context, id string) (*model.Session, error) {
+	var dbSession model.Session
+	err := repo.db.NewSelect().Model(&dbSession).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query session: %v", err)
+	}
+	return &dbSession, nil
+}
+
+func (repo *SessionsRepository) GetSessionByRefreshToken(ctx context.Context, refreshTokenHash string) (*model.Session, error) {
+	var dbSession model.Session
+	err := repo.db.NewSelect().Model(&dbSession).
+		Where("refresh_token_hash = ?", refreshTokenHash).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query session by refresh token: %v", err)
+	}
+	return &dbSession, nil
+}
+
+// RotateRefreshToken replaces the refresh token of a session that has not been revoked.
+// It fails if the refresh token was already rotated, so a refresh token can only be used once.
+func (repo *SessionsRepository) RotateRefreshToken(ctx context.Context, id, oldHash, newHash string, expiresAt time.Time) error {
+	res, err := repo.db.NewUpdate().Model(&model.Session{}).
+		Set("refresh_token_hash = ?", newHash).
+		Set("expires_at = ?", expiresAt).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", id, oldHash).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %v", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("session '%s' with the refresh token does not exist", id)
+	}
+	return nil
+}
+
+func (repo *SessionsRepository) RevokeSession(ctx context.Context, id string) error {
+	_, err := repo.db.NewUpdate().Model(&model.Session{}).
+		Set("revoked_at = ?", time.Now()).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package sessions
+
+import (
+	"context"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+)
+
+type Repository interface {
+	CreateSession(ctx context.Context, session *model.Session) (*model.Session, error)
+	GetSession(ctx context.Context, id string) (*model.Session, error)
+	GetSessionByRefreshToken(ctx context.Context, refreshTokenHash string) (*model.Session, error)
+	RotateRefreshToken(ctx context.Context, id, oldHash, newHash string, expiresAt time.Time) error
+	RevokeSession(ctx context.Context, id string) error
+}
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	model "github.com/AssetPortal/assets-api/pkg/model"
 	mock "github.com/stretchr/testify/mock"
@@ -22,6 +23,122 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
+// ConsumeToken provides a mock function with given fields: ctx, token
+func (_m *Repository) ConsumeToken(ctx context.Context, token string) (*model.Token, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeToken")
+	}
+
+	var r0 *model.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Token, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Token); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ConsumeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeToken'
+type Repository_ConsumeToken_Call struct {
+	*mock.Call
+}
+
+// ConsumeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *Repository_Expecter) ConsumeToken(ctx interface{}, token interface{}) *Repository_ConsumeToken_Call {
+	return &Repository_ConsumeToken_Call{Call: _e.mock.On("ConsumeToken", ctx, token)}
+}
+
+func (_c *Repository_ConsumeToken_Call) Run(run func(ctx context.Context, token string)) *Repository_ConsumeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_ConsumeToken_Call) Return(_a0 *model.Token, _a1 error) *Repository_ConsumeToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ConsumeToken_Call) RunAndReturn(run func(context.Context, string) (*model.Token, error)) *Repository_ConsumeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountOutstandingTokens provides a mock function with given fields: ctx, ip
+func (_m *Repository) CountOutstandingTokens(ctx context.Context, ip string) (int, error) {
+	ret := _m.Called(ctx, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOutstandingTokens")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, ip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, ip)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_CountOutstandingTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOutstandingTokens'
+type Repository_CountOutstandingTokens_Call struct {
+	*mock.Call
+}
+
+// CountOutstandingTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ip string
+func (_e *Repository_Expecter) CountOutstandingTokens(ctx interface{}, ip interface{}) *Repository_CountOutstandingTokens_Call {
+	return &Repository_CountOutstandingTokens_Call{Call: _e.mock.On("CountOutstandingTokens", ctx, ip)}
+}
+
+func (_c *Repository_CountOutstandingTokens_Call) Run(run func(ctx context.Context, ip string)) *Repository_CountOutstandingTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_CountOutstandingTokens_Call) Return(_a0 int, _a1 error) *Repository_CountOutstandingTokens_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_CountOutstandingTokens_Call) RunAndReturn(run func(context.Context, string) (int, error)) *Repository_CountOutstandingTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateToken provides a mock function with given fields: ctx, token
 func (_m *Repository) CreateToken(ctx context.Context, token *model.Token) (*model.Token, error) {
 	ret := _m.Called(ctx, token)
@@ -81,29 +198,27 @@ func (_c *Repository_CreateToken_Call) RunAndReturn(run func(context.Context, *m
 	return _c
 }
 
-// GetToken provides a mock function with given fields: ctx, token
-func (_m *Repository) GetToken(ctx context.Context, token string) (*model.Token, error) {
-	ret := _m.Called(ctx, token)
+// DeleteExpiredTokens provides a mock function with given fields: ctx, usedBefore, limit
+func (_m *Repository) DeleteExpiredTokens(ctx context.Context, usedBefore time.Time, limit int) (int64, error) {
+	ret := _m.Called(ctx, usedBefore, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetToken")
+		panic("no return value specified for DeleteExpiredTokens")
 	}
 
-	var r0 *model.Token
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Token, error)); ok {
-		return rf(ctx, token)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) (int64, error)); ok {
+		return rf(ctx, usedBefore, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Token); ok {
-		r0 = rf(ctx, token)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int64); ok {
+		r0 = rf(ctx, usedBefore, limit)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Token)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, token)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, usedBefore, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -111,78 +226,91 @@ func (_m *Repository) GetToken(ctx context.Context, token string) (*model.Token,
 	return r0, r1
 }
 
-// Repository_GetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetToken'
-type Repository_GetToken_Call struct {
+// Repository_DeleteExpiredTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredTokens'
+type Repository_DeleteExpiredTokens_Call struct {
 	*mock.Call
 }
 
-// GetToken is a helper method to define mock.On call
+// DeleteExpiredTokens is a helper method to define mock.On call
 //   - ctx context.Context
-//   - token string
-func (_e *Repository_Expecter) GetToken(ctx interface{}, token interface{}) *Repository_GetToken_Call {
-	return &Repository_GetToken_Call{Call: _e.mock.On("GetToken", ctx, token)}
+//   - usedBefore time.Time
+//   - limit int
+func (_e *Repository_Expecter) DeleteExpiredTokens(ctx interface{}, usedBefore interface{}, limit interface{}) *Repository_DeleteExpiredTokens_Call {
+	return &Repository_DeleteExpiredTokens_Call{Call: _e.mock.On("DeleteExpiredTokens", ctx, usedBefore, limit)}
 }
 
-func (_c *Repository_GetToken_Call) Run(run func(ctx context.Context, token string)) *Repository_GetToken_Call {
+func (_c *Repository_DeleteExpiredTokens_Call) Run(run func(ctx context.Context, usedBefore time.Time, limit int)) *Repository_DeleteExpiredTokens_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Repository_GetToken_Call) Return(_a0 *model.Token, _a1 error) *Repository_GetToken_Call {
+func (_c *Repository_DeleteExpiredTokens_Call) Return(_a0 int64, _a1 error) *Repository_DeleteExpiredTokens_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Repository_GetToken_Call) RunAndReturn(run func(context.Context, string) (*model.Token, error)) *Repository_GetToken_Call {
+func (_c *Repository_DeleteExpiredTokens_Call) RunAndReturn(run func(context.Context, time.Time, int) (int64, error)) *Repository_DeleteExpiredTokens_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MarkTokenAsUsed provides a mock function with given fields: ctx, token
-func (_m *Repository) MarkTokenAsUsed(ctx context.Context, token string) error {
+// GetToken provides a mock function with given fields: ctx, token
+func (_m *Repository) GetToken(ctx context.Context, token string) (*model.Token, error) {
 	ret := _m.Called(ctx, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MarkTokenAsUsed")
+		panic("no return value specified for GetToken")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 *model.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Token, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Token); ok {
 		r0 = rf(ctx, token)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// Repository_MarkTokenAsUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkTokenAsUsed'
-type Repository_MarkTokenAsUsed_Call struct {
+// Repository_GetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetToken'
+type Repository_GetToken_Call struct {
 	*mock.Call
 }
 
-// MarkTokenAsUsed is a helper method to define mock.On call
+// GetToken is a helper method to define mock.On call
 //   - ctx context.Context
 //   - token string
-func (_e *Repository_Expecter) MarkTokenAsUsed(ctx interface{}, token interface{}) *Repository_MarkTokenAsUsed_Call {
-	return &Repository_MarkTokenAsUsed_Call{Call: _e.mock.On("MarkTokenAsUsed", ctx, token)}
+func (_e *Repository_Expecter) GetToken(ctx interface{}, token interface{}) *Repository_GetToken_Call {
+	return &Repository_GetToken_Call{Call: _e.mock.On("GetToken", ctx, token)}
 }
 
-func (_c *Repository_MarkTokenAsUsed_Call) Run(run func(ctx context.Context, token string)) *Repository_MarkTokenAsUsed_Call {
+func (_c *Repository_GetToken_Call) Run(run func(ctx context.Context, token string)) *Repository_GetToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Repository_MarkTokenAsUsed_Call) Return(_a0 error) *Repository_MarkTokenAsUsed_Call {
-	_c.Call.Return(_a0)
+func (_c *Repository_GetToken_Call) Return(_a0 *model.Token, _a1 error) *Repository_GetToken_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Repository_MarkTokenAsUsed_Call) RunAndReturn(run func(context.Context, string) error) *Repository_MarkTokenAsUsed_Call {
+func (_c *Repository_GetToken_Call) RunAndReturn(run func(context.Context, string) (*model.Token, error)) *Repository_GetToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2,8 +2,10 @@ package tokens
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/AssetPortal/assets-api/pkg/model"
 	"github.com/uptrace/bun"
@@ -41,11 +43,53 @@ func (repo *TokensRepository) GetToken(ctx context.Context, token string) (*mode
 	return &dbToken, nil
 }
 
-// MarkTokenAsUsed sets a token as used to prevent reuse.
-func (repo *TokensRepository) MarkTokenAsUsed(ctx context.Context, token string) error {
-	_, err := repo.db.NewUpdate().Model(&model.Token{}).
+// ConsumeToken marks a token as used if it is unused and not expired, in a single statement.
+// It returns nil if the token does not exist or was already used or expired,
+// so only one of many concurrent requests with the same token can consume it.
+func (repo *TokensRepository) ConsumeToken(ctx context.Context, token string) (*model.Token, error) {
+	var dbToken model.Token
+	err := repo.db.NewUpdate().Model(&dbToken).
 		Set("used = ?", true).
 		Where("token = ?", token).
+		Where("used = false").
+		Where("expires_at > now()").
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to consume token: %v", err)
+	}
+	return &dbToken, nil
+}
+
+// CountOutstandingTokens returns the number of unused and unexpired tokens issued to the ip.
+func (repo *TokensRepository) CountOutstandingTokens(ctx context.Context, ip string) (int, error) {
+	count, err := repo.db.NewSelect().Model((*model.Token)(nil)).
+		Where("ip = ?", ip).
+		Where("used = false").
+		Where("expires_at > now()").
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count outstanding tokens: %v", err)
+	}
+	return count, nil
+}
+
+// DeleteExpiredTokens deletes up to limit tokens that expired, or were used and created before usedBefore.
+// It returns the number of deleted rows.
+func (repo *TokensRepository) DeleteExpiredTokens(ctx context.Context, usedBefore time.Time, limit int) (int64, error) {
+	batch := repo.db.NewSelect().Model((*model.Token)(nil)).
+		Column("id").
+		Where("expires_at <= now()").
+		WhereOr("used = true AND created_at < ?", usedBefore).
+		Limit(limit)
+	res, err := repo.db.NewDelete().Model((*model.Token)(nil)).
+		Where("id IN (?)", batch).
 		Exec(ctx)
-	return err
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired tokens: %v", err)
+	}
+	return res.RowsAffected()
 }
@@ -0,0 +1,66 @@
+package tokens_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AssetPortal/assets-api/pkg/adapters/pgtest"
+	"github.com/AssetPortal/assets-api/pkg/adapters/tokens"
+	"github.com/AssetPortal/assets-api/pkg/model"
+)
+
+const testAddress = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+
+func newTestRepository(t *testing.T) *tokens.TokensRepository {
+	return tokens.NewTokensRepository(pgtest.NewDB(t, "tokens"))
+}
+
+func TestTokensRepository_ConsumeToken_Concurrent(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	_, err := repo.CreateToken(ctx, &model.Token{Token: "nonce", Address: testAddress, ExpiresAt: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+
+	// The requests are released together, each on its own connection.
+	const requests = 20
+	var consumed atomic.Int32
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			token, err := repo.ConsumeToken(ctx, "nonce")
+			assert.NoError(t, err)
+			if token != nil {
+				consumed.Add(1)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), consumed.Load())
+	token, err := repo.GetToken(ctx, "nonce")
+	require.NoError(t, err)
+	assert.True(t, token.Used)
+}
+
+func TestTokensRepository_ConsumeToken_Expired(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	_, err := repo.CreateToken(ctx, &model.Token{Token: "expired", Address: testAddress, ExpiresAt: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+
+	token, err := repo.ConsumeToken(ctx, "expired")
+
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+}
@@ -2,6 +2,7 @@ package tokens
 
 import (
 	"context"
+	"time"
 
 	"github.com/AssetPortal/assets-api/pkg/model"
 )
@@ -9,5 +10,7 @@ import (
 type Repository interface {
 	CreateToken(ctx context.Context, token *model.Token) (*model.Token, error)
 	GetToken(ctx context.Context, token string) (*model.Token, error)
-	MarkTokenAsUsed(ctx context.Context, token string) error
+	ConsumeToken(ctx context.Context, token string) (*model.Token, error)
+	CountOutstandingTokens(ctx context.Context, ip string) (int, error)
+	DeleteExpiredTokens(ctx context.Context, usedBefore time.Time, limit int) (int64, error)
 }
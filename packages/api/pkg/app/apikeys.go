@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/adapters/apikeys"
+	"github.com/AssetPortal/assets-api/pkg/config"
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/sirupsen/logrus"
+)
+
+type APIKeysApp struct {
+	cfg               *config.Configuration
+	apiKeysRepository apikeys.Repository
+	log               *logrus.Logger
+}
+
+func NewAPIKeysApp(
+	cfg *config.Configuration,
+	apiKeysRepository apikeys.Repository,
+	log *logrus.Logger,
+) *APIKeysApp {
+	return &APIKeysApp{
+		cfg:               cfg,
+		apiKeysRepository: apiKeysRepository,
+		log:               log,
+	}
+}
+
+// CreateAPIKey mints a key for the signer, who owns it. The returned key is not stored and cannot be
+// retrieved again. Keys outlive sessions, so the signer must have signed a nonce.
+func (app *APIKeysApp) CreateAPIKey(ctx context.Context, signer *model.Principal, input *model.NewAPIKey) (*model.CreatedAPIKey, error) {
+	if signer.AuthMethod != model.AUTH_METHOD_SIGNATURE {
+		return nil, appError.ErrAPIKeyNotSigned
+	}
+	owner := signer.Address
+	now := time.Now()
+	if input.ExpiresAt.After(now.Add(app.cfg.APIKeyConfiguration.MaxTTL)) {
+		return nil, appError.ErrAPIKeyExpirationTooLong
+	}
+	if limit := app.cfg.APIKeyConfiguration.MaxPerOwner; limit > 0 {
+		keys, err := app.apiKeysRepository.GetAPIKeys(ctx, owner)
+		if err != nil {
+			app.log.Errorf("error getting API keys of owner '%s': '%s'", owner, err)
+			return nil, appError.ErrCreatingAPIKey
+		}
+		active := 0
+		for _, key := range keys {
+			if key.IsValid() {
+				active++
+			}
+		}
+		if active >= limit {
+			return nil, appError.ErrTooManyAPIKeys
+		}
+	}
+	id, err := randomHex(8)
+	if err != nil {
+		app.log.Errorf("error generating API key id: '%s'", err)
+		return nil, appError.ErrCreatingAPIKey
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		app.log.Errorf("error generating API key secret: '%s'", err)
+		return nil, appError.ErrCreatingAPIKey
+	}
+	value := model.NewAPIKeyValue(id, secret)
+	key, err := app.apiKeysRepository.CreateAPIKey(ctx, &model.APIKey{
+		ID:          id,
+		Owner:       owner,
+		Name:        input.Name,
+		KeyHash:     model.HashSecret(value),
+		Permissions: input.Permissions,
+		CreatedAt:   now,
+		ExpiresAt:   input.ExpiresAt,
+	})
+	if err != nil {
+		app.log.Errorf("error creating API key: '%s'", err)
+		return nil, appError.ErrCreatingAPIKey
+	}
+	return &model.CreatedAPIKey{APIKey: key, Key: value}, nil
+}
+
+func (app *APIKeysApp) GetAPIKeys(ctx context.Context, owner string) ([]*model.APIKey, error) {
+	keys, err := app.apiKeysRepository.GetAPIKeys(ctx, owner)
+	if err != nil {
+		app.log.Errorf("error getting API keys of owner '%s': '%s'", owner, err)
+		return nil, appError.ErrGettingAPIKeys
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes a key of the signer, who must have signed a nonce like to create it.
+func (app *APIKeysApp) RevokeAPIKey(ctx context.Context, id string, signer *model.Principal) error {
+	if signer.AuthMethod != model.AUTH_METHOD_SIGNATURE {
+		return appError.ErrAPIKeyNotSigned
+	}
+	err := app.apiKeysRepository.RevokeAPIKey(ctx, id, signer.Address)
+	if err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			return appError.ErrAPIKeyDoesNotExist
+		}
+		app.log.Errorf("error revoking API key '%s': '%s'", id, err)
+		return appError.ErrRevokingAPIKey
+	}
+	return nil
+}
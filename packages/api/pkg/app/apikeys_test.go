@@ -0,0 +1,132 @@
+package app_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	apiKeysMock "github.com/AssetPortal/assets-api/pkg/adapters/apikeys/mocks"
+	"github.com/AssetPortal/assets-api/pkg/app"
+	"github.com/AssetPortal/assets-api/pkg/config"
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func apiKeysConfig() *config.Configuration {
+	return &config.Configuration{
+		APIKeyConfiguration: config.APIKeyConfiguration{
+			MaxTTL:      24 * time.Hour,
+			MaxPerOwner: 2,
+		},
+	}
+}
+
+func TestAPIKeysApp_CreateAPIKey_Success(t *testing.T) {
+	mockAPIKeysRepository := new(apiKeysMock.Repository)
+	app := app.NewAPIKeysApp(apiKeysConfig(), mockAPIKeysRepository, logrus.New())
+
+	var stored *model.APIKey
+	mockAPIKeysRepository.On("GetAPIKeys", mock.Anything, "ownerAddress").Return([]*model.APIKey{}, nil).Once()
+	mockAPIKeysRepository.On("CreateAPIKey", mock.Anything, mock.AnythingOfType("*model.APIKey")).
+		Return(func(_ context.Context, key *model.APIKey) (*model.APIKey, error) {
+			stored = key
+			return key, nil
+		}).Once()
+
+	created, err := app.CreateAPIKey(context.Background(), signedBy("ownerAddress"), &model.NewAPIKey{
+		Name:        "sync",
+		Permissions: []string{model.PERMISSION_CREATE},
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ownerAddress", stored.Owner)
+	assert.Equal(t, model.HashSecret(created.Key), stored.KeyHash)
+	id, err := model.ParseAPIKeyID(created.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, stored.ID, id)
+	mockAPIKeysRepository.AssertExpectations(t)
+}
+
+func TestAPIKeysApp_CreateAPIKey_ExpirationTooLong(t *testing.T) {
+	mockAPIKeysRepository := new(apiKeysMock.Repository)
+	app := app.NewAPIKeysApp(apiKeysConfig(), mockAPIKeysRepository, logrus.New())
+
+	created, err := app.CreateAPIKey(context.Background(), signedBy("ownerAddress"), &model.NewAPIKey{
+		Permissions: []string{model.PERMISSION_CREATE},
+		ExpiresAt:   time.Now().Add(48 * time.Hour),
+	})
+
+	assert.Nil(t, created)
+	assert.Equal(t, appError.ErrAPIKeyExpirationTooLong, err)
+	mockAPIKeysRepository.AssertExpectations(t)
+}
+
+func TestAPIKeysApp_CreateAPIKey_TooMany(t *testing.T) {
+	mockAPIKeysRepository := new(apiKeysMock.Repository)
+	app := app.NewAPIKeysApp(apiKeysConfig(), mockAPIKeysRepository, logrus.New())
+
+	active := &model.APIKey{ExpiresAt: time.Now().Add(time.Hour)}
+	expired := &model.APIKey{ExpiresAt: time.Now().Add(-time.Hour)}
+	mockAPIKeysRepository.On("GetAPIKeys", mock.Anything, "ownerAddress").
+		Return([]*model.APIKey{active, expired, active}, nil).Once()
+
+	created, err := app.CreateAPIKey(context.Background(), signedBy("ownerAddress"), &model.NewAPIKey{
+		Permissions: []string{model.PERMISSION_CREATE},
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+
+	assert.Nil(t, created)
+	assert.Equal(t, appError.ErrTooManyAPIKeys, err)
+	mockAPIKeysRepository.AssertExpectations(t)
+}
+
+func TestAPIKeysApp_RevokeAPIKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+		err     error
+	}{
+		{name: "success"},
+		{name: "does not exist", repoErr: fmt.Errorf("API key does not exist"), err: appError.ErrAPIKeyDoesNotExist},
+		{name: "failure", repoErr: fmt.Errorf("db error"), err: appError.ErrRevokingAPIKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKeysRepository := new(apiKeysMock.Repository)
+			app := app.NewAPIKeysApp(apiKeysConfig(), mockAPIKeysRepository, logrus.New())
+
+			mockAPIKeysRepository.On("RevokeAPIKey", mock.Anything, "0123456789abcdef", "ownerAddress").Return(tt.repoErr).Once()
+
+			err := app.RevokeAPIKey(context.Background(), "0123456789abcdef", signedBy("ownerAddress"))
+
+			assert.Equal(t, tt.err, err)
+			mockAPIKeysRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIKeysApp_NotSigned(t *testing.T) {
+	mockAPIKeysRepository := new(apiKeysMock.Repository)
+	app := app.NewAPIKeysApp(apiKeysConfig(), mockAPIKeysRepository, logrus.New())
+
+	for _, method := range []string{model.AUTH_METHOD_SESSION, model.AUTH_METHOD_DEV} {
+		signer := &model.Principal{Address: "ownerAddress", AuthMethod: method}
+
+		created, err := app.CreateAPIKey(context.Background(), signer, &model.NewAPIKey{
+			Permissions: []string{model.PERMISSION_CREATE},
+			ExpiresAt:   time.Now().Add(time.Hour),
+		})
+		assert.Nil(t, created)
+		assert.Equal(t, appError.ErrAPIKeyNotSigned, err, method)
+
+		err = app.RevokeAPIKey(context.Background(), "0123456789abcdef", signer)
+		assert.Equal(t, appError.ErrAPIKeyNotSigned, err, method)
+	}
+	mockAPIKeysRepository.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+	mockAPIKeysRepository.AssertNotCalled(t, "RevokeAPIKey", mock.Anything, mock.Anything, mock.Anything)
+}
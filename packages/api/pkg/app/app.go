@@ -4,10 +4,14 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/AssetPortal/assets-api/pkg/adapters/assets"
+	"github.com/AssetPortal/assets-api/pkg/adapters/delegates"
 	"github.com/AssetPortal/assets-api/pkg/adapters/storage"
 	"github.com/AssetPortal/assets-api/pkg/adapters/tokens"
 	"github.com/AssetPortal/assets-api/pkg/config"
@@ -18,12 +22,14 @@ import (
 )
 
 type AssetsApp struct {
-	cfg              *config.Configuration
-	db               *bun.DB
-	tokensRepository tokens.Repository
-	assetsRepository assets.Repository
-	storageClient    storage.Client
-	log              *logrus.Logger
+	cfg                 *config.Configuration
+	db                  *bun.DB
+	tokensRepository    tokens.Repository
+	assetsRepository    assets.Repository
+	delegatesRepository delegates.Repository
+	storageClient       storage.Client
+	networks            model.Networks
+	log                 *logrus.Logger
 }
 
 func NewAssetsApp(
@@ -31,20 +37,49 @@ func NewAssetsApp(
 	db *bun.DB,
 	tokensRepository tokens.Repository,
 	assetsRepository assets.Repository,
+	delegatesRepository delegates.Repository,
 	storageClient storage.Client,
 	log *logrus.Logger,
 ) *AssetsApp {
 	return &AssetsApp{
-		cfg:              cfg,
-		db:               db,
-		tokensRepository: tokensRepository,
-		assetsRepository: assetsRepository,
-		storageClient:    storageClient,
-		log:              log,
+		cfg:                 cfg,
+		db:                  db,
+		tokensRepository:    tokensRepository,
+		assetsRepository:    assetsRepository,
+		delegatesRepository: delegatesRepository,
+		storageClient:       storageClient,
+		networks:            model.DefaultNetworks(),
+		log:                 log,
 	}
 }
 
-func (app *AssetsApp) CreateToken(ctx context.Context) (*model.Token, error) {
+// WithNetworks sets the networks that assets can be registered on, instead of the default ones.
+func (app *AssetsApp) WithNetworks(networks model.Networks) *AssetsApp {
+	app.networks = networks
+	return app
+}
+
+// Networks returns the networks that assets can be registered on.
+func (app *AssetsApp) Networks() model.Networks {
+	return app.networks
+}
+
+func (app *AssetsApp) CreateToken(ctx context.Context, input *model.CreateTokenInput) (*model.Token, error) {
+	if input.IsSIWS() && app.cfg.AuthConfiguration.Domain == "" {
+		return nil, appError.ErrSIWSDisabled
+	}
+	// Outstanding nonces are capped per client IP, not per address, so that nobody can use up
+	// the nonces of someone else's address.
+	if limit := app.cfg.NonceConfiguration.MaxOutstanding; limit > 0 {
+		outstanding, err := app.tokensRepository.CountOutstandingTokens(ctx, input.IP)
+		if err != nil {
+			app.log.Errorf("error counting outstanding tokens: '%s'", err)
+			return nil, appError.ErrCountingTokens
+		}
+		if outstanding >= limit {
+			return nil, appError.ErrTooManyOutstandingTokens
+		}
+	}
 	tokenBytes := make([]byte, 32)
 	_, err := rand.Read(tokenBytes)
 	if err != nil {
@@ -52,8 +87,19 @@ func (app *AssetsApp) CreateToken(ctx context.Context) (*model.Token, error) {
 		return nil, appError.ErrGeneratingToken
 	}
 	now := time.Now()
+	route := input.Route()
 	newToken := &model.Token{
 		Token:     hex.EncodeToString(tokenBytes),
+		Address:   input.Address,
+		Action:    input.Action,
+		Method:    route.Method,
+		Path:      route.Path,
+		AssetID:   input.AssetID,
+		Delegate:  input.Delegate,
+		Recipient: input.Recipient,
+		APIKeyID:  input.APIKeyID,
+		Digest:    input.Digest,
+		IP:        input.IP,
 		CreatedAt: now,
 		ExpiresAt: now.Add(app.cfg.TokenExpiration),
 		Used:      false,
@@ -63,22 +109,107 @@ func (app *AssetsApp) CreateToken(ctx context.Context) (*model.Token, error) {
 		app.log.Errorf("error creating token: '%s'", err)
 		return nil, appError.ErrCreatingToken
 	}
+	if input.IsSIWS() {
+		token.Message = model.NewSIWSMessage(
+			token,
+			app.cfg.AuthConfiguration.Domain,
+			app.cfg.AuthConfiguration.URI,
+			app.networks.AddressNetwork(token.Address),
+			token.Statement(),
+		).String()
+	}
 	return token, nil
 }
 
-func (app *AssetsApp) CreateAsset(ctx context.Context, asset *model.Asset) (*model.Asset, error) {
-	token, err := app.assetsRepository.CreateAsset(ctx, asset)
-	if err != nil {
-		if strings.Contains(err.Error(), "assets_id_key") {
-			return nil, appError.ErrCreatingAssetIDExists
+// CreateAsset creates the asset on behalf of the signer, who owns it. The address the signer signed
+// with must be encoded for the blockchain of the asset.
+func (app *AssetsApp) CreateAsset(ctx context.Context, asset *model.Asset, signer *model.Principal) (*model.Asset, error) {
+	return app.createAsset(ctx, app.assetsRepository, asset, signer)
+}
+
+func (app *AssetsApp) createAsset(ctx context.Context, repo assets.Repository, asset *model.Asset, signer *model.Principal) (*model.Asset, error) {
+	if err := app.checkBlockchain(signer, nil, asset.Blockchain); err != nil {
+		return nil, err
+	}
+	var created *model.Asset
+	err := app.inTx(ctx, repo, appError.ErrCreatingAsset, func(ctx context.Context, repo assets.Repository) error {
+		var err error
+		created, err = repo.CreateAsset(ctx, asset)
+		if err != nil {
+			if strings.Contains(err.Error(), "assets_id_key") {
+				return appError.ErrCreatingAssetIDExists
+			}
+			app.log.Errorf("error creating asset: '%s'", err)
+			return appError.ErrCreatingAsset
 		}
-		app.log.Errorf("error creating asset: '%s'", err)
-		return nil, appError.ErrCreatingAsset
+		return app.recordRevision(ctx, repo, asset.ID, model.REVISION_CREATE, signer)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return token, nil
+	return created, nil
+}
+
+// checkBlockchain returns an error wrapping ErrInvalidBlockchain if the asset moves from a blockchain
+// to one that is not an enabled network, and ErrAddressNotOnBlockchain if it moves to one that the
+// address of the signer is not encoded for. Assets that stay on their blockchain are not checked.
+func (app *AssetsApp) checkBlockchain(signer *model.Principal, from, to *string) error {
+	if to == nil || (from != nil && *from == *to) {
+		return nil
+	}
+	if err := app.networks.ValidateBlockchain(*to); err != nil {
+		return fmt.Errorf("%w: %s", appError.ErrInvalidBlockchain, err)
+	}
+	if signer.CheckNetwork(app.networks.Get(*to)) != nil {
+		return appError.ErrAddressNotOnBlockchain
+	}
+	return nil
+}
+
+// inTx runs fn in a transaction of the repository. The errors of fn are returned as they are,
+// and failed if the transaction cannot be committed.
+func (app *AssetsApp) inTx(ctx context.Context, repo assets.Repository, failed error, fn func(ctx context.Context, repo assets.Repository) error) error {
+	var fnErr error
+	err := repo.RunInTx(ctx, func(ctx context.Context, repo assets.Repository) error {
+		fnErr = fn(ctx, repo)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		app.log.Errorf("error committing transaction: '%s'", err)
+		return failed
+	}
+	return nil
+}
+
+// recordRevision stores the asset as it is after the operation of the signer in its history.
+func (app *AssetsApp) recordRevision(ctx context.Context, repo assets.Repository, id, operation string, signer *model.Principal) error {
+	if err := repo.CreateRevision(ctx, model.NewAssetRevision(id, operation, signer)); err != nil {
+		app.log.Errorf("error recording the %s of asset '%s': '%s'", operation, id, err)
+		return appError.ErrRecordingRevision
+	}
+	return nil
 }
 
-func (app *AssetsApp) GetAssetByID(ctx context.Context, id string) (*model.Asset, error) {
+// GetAssetByID returns the asset, or nil if it does not exist. With fields, only their columns are read.
+func (app *AssetsApp) GetAssetByID(ctx context.Context, id string, fields []string) (*model.Asset, error) {
+	if len(fields) > 0 {
+		// The version is always read, as the ETag of the asset.
+		if !slices.Contains(fields, "version") {
+			fields = append(slices.Clip(fields), "version")
+		}
+		assets, err := app.assetsRepository.GetAssets(ctx, &model.GetAssetsInput{ID: &id, Projection: fields})
+		if err != nil {
+			app.log.Errorf("error getting asset by id '%s': '%s'", id, err)
+			return nil, appError.ErrGettingAsset
+		}
+		if len(assets) == 0 {
+			return nil, nil
+		}
+		return assets[0], nil
+	}
 	asset, err := app.assetsRepository.GetAssetByID(ctx, id)
 	if err != nil {
 		app.log.Errorf("error getting asset by id '%s': '%s'", id, err)
@@ -87,13 +218,67 @@ func (app *AssetsApp) GetAssetByID(ctx context.Context, id string) (*model.Asset
 	return asset, nil
 }
 
-func (app *AssetsApp) GetAssets(ctx context.Context, filters *model.GetAssetsInput) ([]*model.Asset, error) {
-	asset, err := app.assetsRepository.GetAssets(ctx, filters)
+// GetAssetsByIDs returns the assets found for the ids, with only the fields, and the ids not found.
+func (app *AssetsApp) GetAssetsByIDs(ctx context.Context, ids []string, fields []string) (*model.AssetBatch, error) {
+	assets, err := app.assetsRepository.GetAssetsByIDs(ctx, ids, fields)
 	if err != nil {
-		app.log.Errorf("error getting assets: '%s'", err)
+		app.log.Errorf("error getting assets by ids: '%s'", err)
 		return nil, appError.ErrGettingAsset
 	}
-	return asset, nil
+	batch, err := model.NewAssetBatch(ids, assets, fields)
+	if err != nil {
+		app.log.Errorf("error projecting assets: '%s'", err)
+		return nil, appError.ErrGettingAsset
+	}
+	return batch, nil
+}
+
+// GetAssets returns a page of assets and its metadata: whether there are more, the cursor of the
+// next page and, when requested, the total number of matching assets.
+func (app *AssetsApp) GetAssets(ctx context.Context, filters *model.GetAssetsInput) ([]*model.Asset, *model.Meta, error) {
+	for _, blockchain := range filters.Blockchain {
+		if err := app.networks.ValidateBlockchainFilter(blockchain); err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", appError.ErrInvalidBlockchain, err)
+		}
+	}
+	// One more asset than the limit is requested to know whether there is a next page.
+	query := *filters
+	if filters.Limit != nil {
+		limit := *filters.Limit + 1
+		query.Limit = &limit
+	}
+	assets, err := app.assetsRepository.GetAssets(ctx, &query)
+	if err != nil {
+		app.log.Errorf("error getting assets: '%s'", err)
+		return nil, nil, appError.ErrGettingAsset
+	}
+
+	meta := &model.Meta{}
+	if filters.Offset != nil {
+		meta.Offset = *filters.Offset
+	}
+	if filters.Limit != nil {
+		meta.Limit = *filters.Limit
+	}
+	if filters.Limit != nil && len(assets) > *filters.Limit {
+		assets = assets[:*filters.Limit]
+		meta.HasMore = true
+		// Ranked results cannot be resumed with a cursor, only with an offset.
+		cursor := model.NewCursor(assets[len(assets)-1], filters.Sort)
+		if cursor != nil && !filters.IsRanked() {
+			meta.NextCursor = cursor.Encode()
+		}
+	}
+
+	if filters.Count != nil && *filters.Count {
+		total, err := app.assetsRepository.CountAssets(ctx, filters)
+		if err != nil {
+			app.log.Errorf("error counting assets: '%s'", err)
+			return nil, nil, appError.ErrGettingAsset
+		}
+		meta.Total = &total
+	}
+	return assets, meta, nil
 }
 
 func (app *AssetsApp) UploadFile(ctx context.Context, fileKey string, fileBytes []byte, contentType string) (*model.URL, error) {
@@ -107,30 +292,278 @@ func (app *AssetsApp) UploadFile(ctx context.Context, fileKey string, fileBytes
 	}, nil
 }
 
-func (app *AssetsApp) UpdateAsset(ctx context.Context, asset *model.Asset) error {
-	err := app.assetsRepository.UpdateAsset(ctx, asset)
+// authorize returns the asset if the signer is the owner or a delegate allowed to perform the action.
+// With a version, the asset must be at that version.
+func (app *AssetsApp) authorize(ctx context.Context, repo assets.Repository, id, signer, action string, version *int) (*model.Asset, error) {
+	asset, err := repo.GetAssetByID(ctx, id)
 	if err != nil {
-		if strings.Contains(err.Error(), "does not exist") {
-			return appError.ErrAssetDoesNotBelongToTheUser
+		app.log.Errorf("error getting asset by id '%s': '%s'", id, err)
+		return nil, appError.ErrGettingAsset
+	}
+	if asset == nil {
+		return nil, appError.ErrAssetDoesNotBelongToTheUser
+	}
+	if asset.Address != signer {
+		delegate, err := app.delegatesRepository.GetDelegate(ctx, asset.Address, signer)
+		if err != nil {
+			app.log.Errorf("error getting delegate '%s' of owner '%s': '%s'", signer, asset.Address, err)
+			return nil, appError.ErrGettingDelegate
+		}
+		if delegate == nil || !delegate.Can(action) {
+			return nil, appError.ErrAssetDoesNotBelongToTheUser
 		}
-		app.log.Errorf("error updating asset by id '%s': '%s'", asset.ID, err)
-		return appError.ErrUpdatingAsset
 	}
-	return nil
+	if version != nil && asset.Version != *version {
+		return nil, appError.ErrAssetVersionMismatch
+	}
+	return asset, nil
 }
 
-func (app *AssetsApp) DeleteAsset(ctx context.Context, id, address string) error {
-	err := app.assetsRepository.DeleteAsset(ctx, id, address)
+// UpdateAsset updates the asset on behalf of the signer, who must be the owner or a delegate. An asset
+// can only be moved to a blockchain that the address of the signer is encoded for. With a version, the asset is only updated if it's still at that version. The new version is set in the asset.
+func (app *AssetsApp) UpdateAsset(ctx context.Context, asset *model.Asset, version *int, signer *model.Principal) error {
+	return app.updateAsset(ctx, app.assetsRepository, asset, version, signer)
+}
+
+func (app *AssetsApp) updateAsset(ctx context.Context, repo assets.Repository, asset *model.Asset, version *int, signer *model.Principal) error {
+	return app.inTx(ctx, repo, appError.ErrUpdatingAsset, func(ctx context.Context, repo assets.Repository) error {
+		current, err := app.authorize(ctx, repo, asset.ID, signer.Address, model.ACTION_UPDATE_ASSET, version)
+		if err != nil {
+			return err
+		}
+		if err := app.checkBlockchain(signer, current.Blockchain, asset.Blockchain); err != nil {
+			return err
+		}
+		asset.Address = current.Address
+		err = repo.UpdateAsset(ctx, asset, version)
+		if err != nil {
+			if strings.Contains(err.Error(), "is not at version") {
+				return appError.ErrAssetVersionMismatch
+			}
+			if strings.Contains(err.Error(), "does not exist") {
+				return appError.ErrAssetDoesNotBelongToTheUser
+			}
+			app.log.Errorf("error updating asset by id '%s': '%s'", asset.ID, err)
+			return appError.ErrUpdatingAsset
+		}
+		return app.recordRevision(ctx, repo, asset.ID, model.REVISION_UPDATE, signer)
+	})
+}
+
+// PatchAsset applies the patch of the media type to the asset on behalf of the signer, who must be
+// the owner or a delegate. With a version, the asset is only patched if it's still at that version.
+// Patches that cannot be applied return an error wrapping ErrInvalidPatch.
+func (app *AssetsApp) PatchAsset(ctx context.Context, id, mediaType string, patch json.RawMessage, version *int, signer *model.Principal) (*model.Asset, error) {
+	var asset *model.Asset
+	err := app.inTx(ctx, app.assetsRepository, appError.ErrUpdatingAsset, func(ctx context.Context, repo assets.Repository) error {
+		var err error
+		asset, err = app.authorize(ctx, repo, id, signer.Address, model.ACTION_UPDATE_ASSET, version)
+		if err != nil {
+			return err
+		}
+		fields, err := asset.Patch(mediaType, patch)
+		if err != nil {
+			return fmt.Errorf("%w: %s", appError.ErrInvalidPatch, err)
+		}
+		if err := app.checkBlockchain(signer, asset.Blockchain, fields.Blockchain); err != nil {
+			return err
+		}
+		asset.Blockchain, asset.Description, asset.Image, asset.Social = fields.Blockchain, fields.Description, fields.Image, fields.Social
+		err = repo.PatchAsset(ctx, asset, version)
+		if err != nil {
+			if strings.Contains(err.Error(), "is not at version") {
+				return appError.ErrAssetVersionMismatch
+			}
+			if strings.Contains(err.Error(), "does not exist") {
+				return appError.ErrAssetDoesNotBelongToTheUser
+			}
+			app.log.Errorf("error patching asset by id '%s': '%s'", id, err)
+			return appError.ErrUpdatingAsset
+		}
+		return app.recordRevision(ctx, repo, id, model.REVISION_UPDATE, signer)
+	})
 	if err != nil {
-		if strings.Contains(err.Error(), "does not exist") {
-			return appError.ErrAssetDoesNotBelongToTheUser
+		return nil, err
+	}
+	return asset, nil
+}
+
+// DeleteAsset deletes the asset on behalf of the signer, who must be the owner or a delegate.
+// With a version, the asset is only deleted if it's still at that version.
+func (app *AssetsApp) DeleteAsset(ctx context.Context, id string, version *int, signer *model.Principal) error {
+	return app.deleteAsset(ctx, app.assetsRepository, id, version, signer)
+}
+
+func (app *AssetsApp) deleteAsset(ctx context.Context, repo assets.Repository, id string, version *int, signer *model.Principal) error {
+	return app.inTx(ctx, repo, appError.ErrDeletingAsset, func(ctx context.Context, repo assets.Repository) error {
+		current, err := app.authorize(ctx, repo, id, signer.Address, model.ACTION_DELETE_ASSET, version)
+		if err != nil {
+			return err
+		}
+		err = repo.DeleteAsset(ctx, id, current.Address, version)
+		if err != nil {
+			if strings.Contains(err.Error(), "is not at version") {
+				return appError.ErrAssetVersionMismatch
+			}
+			if strings.Contains(err.Error(), "does not exist") {
+				return appError.ErrAssetDoesNotBelongToTheUser
+			}
+			app.log.Errorf("error deleting asset by id '%s' and address '%s': '%s'", id, current.Address, err)
+			return appError.ErrDeletingAsset
+		}
+		return app.recordRevision(ctx, repo, id, model.REVISION_DELETE, signer)
+	})
+}
+
+// RestoreAsset undeletes an asset of the signer, who must be its owner, within the restore window.
+func (app *AssetsApp) RestoreAsset(ctx context.Context, id string, signer *model.Principal) (*model.Asset, error) {
+	deletedAfter := time.Now().Add(-app.cfg.AssetsConfiguration.RestoreWindow)
+	var asset *model.Asset
+	err := app.inTx(ctx, app.assetsRepository, appError.ErrRestoringAsset, func(ctx context.Context, repo assets.Repository) error {
+		var err error
+		asset, err = repo.RestoreAsset(ctx, id, signer.Address, deletedAfter)
+		if err != nil {
+			app.log.Errorf("error restoring asset by id '%s' and address '%s': '%s'", id, signer.Address, err)
+			return appError.ErrRestoringAsset
+		}
+		if asset == nil {
+			return appError.ErrAssetNotRestorable
 		}
-		app.log.Errorf("error deleting asset by id '%s' and address '%s': '%s'", id, address, err)
-		return appError.ErrDeletingAsset
+		return app.recordRevision(ctx, repo, id, model.REVISION_RESTORE, signer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// checkHistory returns ErrHistoryNotFound if the asset was deleted or purged. Like the asset, its
+// history is hidden then: its revisions, with their nonces, and its transfers.
+func (app *AssetsApp) checkHistory(ctx context.Context, id string) error {
+	asset, err := app.assetsRepository.GetAssetByID(ctx, id)
+	if err != nil {
+		app.log.Errorf("error getting asset by id '%s': '%s'", id, err)
+		return appError.ErrGettingAsset
+	}
+	if asset == nil {
+		return appError.ErrHistoryNotFound
 	}
 	return nil
 }
 
+// GetRevisions returns a page of the revisions of the asset, the latest first.
+func (app *AssetsApp) GetRevisions(ctx context.Context, id string, pagination *model.Pagination) ([]*model.AssetRevision, error) {
+	if err := app.checkHistory(ctx, id); err != nil {
+		return nil, err
+	}
+	revisions, err := app.assetsRepository.GetRevisions(ctx, id, *pagination.Limit, *pagination.Offset)
+	if err != nil {
+		app.log.Errorf("error getting revisions of asset '%s': '%s'", id, err)
+		return nil, appError.ErrGettingRevisions
+	}
+	return revisions, nil
+}
+
+// GetAssetAsOf returns the revision of the asset by its number, or the one in effect at the time.
+// It returns nil if there is none.
+func (app *AssetsApp) GetAssetAsOf(ctx context.Context, input *model.GetAssetAsOfInput) (*model.AssetRevision, error) {
+	if err := app.checkHistory(ctx, input.ID); err != nil {
+		return nil, err
+	}
+	var revision *model.AssetRevision
+	var err error
+	if input.Revision != nil {
+		revision, err = app.assetsRepository.GetRevision(ctx, input.ID, *input.Revision)
+	} else {
+		revision, err = app.assetsRepository.GetRevisionAt(ctx, input.ID, *input.At)
+	}
+	if err != nil {
+		app.log.Errorf("error getting revision of asset '%s': '%s'", input.ID, err)
+		return nil, appError.ErrGettingRevisions
+	}
+	return revision, nil
+}
+
+// DiffRevisions returns the changes of the asset between two of its revisions.
+func (app *AssetsApp) DiffRevisions(ctx context.Context, id string, from, to int) (*model.AssetDiff, error) {
+	if err := app.checkHistory(ctx, id); err != nil {
+		return nil, err
+	}
+	revisions := make([]*model.AssetRevision, 2)
+	for i, number := range []int{from, to} {
+		revision, err := app.assetsRepository.GetRevision(ctx, id, number)
+		if err != nil {
+			app.log.Errorf("error getting revision %d of asset '%s': '%s'", number, id, err)
+			return nil, appError.ErrGettingRevisions
+		}
+		if revision == nil {
+			return nil, appError.ErrRevisionDoesNotExist
+		}
+		revisions[i] = revision
+	}
+	diff, err := model.DiffRevisions(revisions[0], revisions[1])
+	if err != nil {
+		app.log.Errorf("error diffing revisions %d and %d of asset '%s': '%s'", from, to, id, err)
+		return nil, appError.ErrGettingRevisions
+	}
+	return diff, nil
+}
+
+// BulkAssets applies the items on behalf of the signer, who creates assets and must own or be a
+// delegate of the others. Atomic batches are applied in a transaction: when an item fails, the
+// others are rolled back with ErrBulkRolledBack. Otherwise each item is applied on its own.
+func (app *AssetsApp) BulkAssets(ctx context.Context, bulk *model.BulkAssets, signer *model.Principal) ([]*model.BulkResult, error) {
+	results := make([]*model.BulkResult, len(bulk.Items))
+	for i, item := range bulk.Items {
+		results[i] = &model.BulkResult{Index: i, Op: item.Op, ID: item.ID}
+	}
+	if !bulk.IsAtomic() {
+		for i := range bulk.Items {
+			app.applyBulkItem(ctx, app.assetsRepository, &bulk.Items[i], signer, results[i])
+		}
+		return results, nil
+	}
+
+	err := app.assetsRepository.RunInTx(ctx, func(ctx context.Context, repo assets.Repository) error {
+		for i := range bulk.Items {
+			app.applyBulkItem(ctx, repo, &bulk.Items[i], signer, results[i])
+			if results[i].Err != nil {
+				return results[i].Err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		return results, nil
+	}
+	failed := false
+	for _, result := range results {
+		if result.Err == nil {
+			result.Asset = nil
+			result.Err = appError.ErrBulkRolledBack
+		} else if result.Err != appError.ErrBulkRolledBack {
+			failed = true
+		}
+	}
+	// The items succeeded, but the transaction could not be committed.
+	if !failed {
+		app.log.Errorf("error committing the batch of assets: '%s'", err)
+		return nil, appError.ErrApplyingBulk
+	}
+	return results, nil
+}
+
+func (app *AssetsApp) applyBulkItem(ctx context.Context, repo assets.Repository, item *model.BulkItem, signer *model.Principal, result *model.BulkResult) {
+	switch item.Op {
+	case model.BULK_CREATE:
+		result.Asset, result.Err = app.createAsset(ctx, repo, item.Asset(signer.Address), signer)
+	case model.BULK_UPDATE:
+		result.Err = app.updateAsset(ctx, repo, item.Asset(signer.Address), nil, signer)
+	case model.BULK_DELETE:
+		result.Err = app.deleteAsset(ctx, repo, item.ID, nil, signer)
+	}
+}
+
 func (app *AssetsApp) Config() *config.Configuration {
 	return app.cfg
 }
@@ -2,11 +2,14 @@ package app_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/AssetPortal/assets-api/pkg/adapters/assets"
 	assetsMock "github.com/AssetPortal/assets-api/pkg/adapters/assets/mocks"
+	delegatesMock "github.com/AssetPortal/assets-api/pkg/adapters/delegates/mocks"
 	tokensMock "github.com/AssetPortal/assets-api/pkg/adapters/tokens/mocks"
 	"github.com/AssetPortal/assets-api/pkg/app"
 	"github.com/AssetPortal/assets-api/pkg/config"
@@ -15,8 +18,29 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
+// runInTx makes the mock run the transactions with itself.
+func runInTx(repo *assetsMock.Repository) {
+	repo.On("RunInTx", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(ctx context.Context, repo assets.Repository) error) error {
+			return fn(ctx, repo)
+		}).Maybe()
+}
+
+func signedBy(address string) *model.Principal {
+	return &model.Principal{Address: address, AuthMethod: model.AUTH_METHOD_SIGNATURE, Nonce: "nonce"}
+}
+
+// revisionOf matches the revision of the operation on the asset, made by the address with its nonce.
+func revisionOf(id, operation, address string) interface{} {
+	return mock.MatchedBy(func(revision *model.AssetRevision) bool {
+		return revision.AssetID == id && revision.Operation == operation && revision.Address == address &&
+			revision.Nonce != nil && *revision.Nonce == "nonce"
+	})
+}
+
 func TestAssetsApp_CreateToken_Success(t *testing.T) {
 	mockTokensRepository := new(tokensMock.Repository)
 	mockLogger := logrus.New()
@@ -27,6 +51,7 @@ func TestAssetsApp_CreateToken_Success(t *testing.T) {
 		mockTokensRepository,
 		nil,
 		nil,
+		nil,
 		mockLogger,
 	)
 
@@ -40,7 +65,7 @@ func TestAssetsApp_CreateToken_Success(t *testing.T) {
 	mockTokensRepository.On("CreateToken", mock.Anything, mock.AnythingOfType("*model.Token")).
 		Return(expectedToken, nil).Once()
 
-	token, err := app.CreateToken(context.Background())
+	token, err := app.CreateToken(context.Background(), &model.CreateTokenInput{Address: "userAddress", Action: model.ACTION_CREATE_ASSET})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, token)
@@ -59,13 +84,14 @@ func TestAssetsApp_CreateToken_Failure(t *testing.T) {
 		mockTokensRepository,
 		nil,
 		nil,
+		nil,
 		mockLogger,
 	)
 
 	mockTokensRepository.On("CreateToken", mock.Anything, mock.AnythingOfType("*model.Token")).
 		Return(nil, fmt.Errorf("database error")).Once()
 
-	token, err := app.CreateToken(context.Background())
+	token, err := app.CreateToken(context.Background(), &model.CreateTokenInput{Address: "userAddress", Action: model.ACTION_CREATE_ASSET})
 
 	assert.Error(t, err)
 	assert.Nil(t, token)
@@ -85,6 +111,7 @@ func TestAssetsApp_CreateToken_Expiration(t *testing.T) {
 		mockTokensRepository,
 		nil,
 		nil,
+		nil,
 		mockLogger,
 	)
 
@@ -98,7 +125,7 @@ func TestAssetsApp_CreateToken_Expiration(t *testing.T) {
 	mockTokensRepository.On("CreateToken", mock.Anything, mock.AnythingOfType("*model.Token")).
 		Return(expectedToken, nil).Once()
 
-	token, err := app.CreateToken(context.Background())
+	token, err := app.CreateToken(context.Background(), &model.CreateTokenInput{Address: "userAddress", Action: model.ACTION_CREATE_ASSET})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, token)
@@ -117,6 +144,7 @@ func TestAssetsApp_CreateToken_LongExpiration(t *testing.T) {
 		mockTokensRepository,
 		nil,
 		nil,
+		nil,
 		mockLogger,
 	)
 
@@ -130,7 +158,7 @@ func TestAssetsApp_CreateToken_LongExpiration(t *testing.T) {
 	mockTokensRepository.On("CreateToken", mock.Anything, mock.AnythingOfType("*model.Token")).
 		Return(expectedToken, nil).Once()
 
-	token, err := app.CreateToken(context.Background())
+	token, err := app.CreateToken(context.Background(), &model.CreateTokenInput{Address: "userAddress", Action: model.ACTION_CREATE_ASSET})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, token)
@@ -138,6 +166,144 @@ func TestAssetsApp_CreateToken_LongExpiration(t *testing.T) {
 
 	mockTokensRepository.AssertExpectations(t)
 }
+
+func TestAssetsApp_CreateToken_OutstandingLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		byIP int
+		err  error
+	}{
+		{name: "below the limit", byIP: 2},
+		{name: "ip at the limit", byIP: 3, err: appError.ErrTooManyOutstandingTokens},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTokensRepository := new(tokensMock.Repository)
+			app := app.NewAssetsApp(
+				&config.Configuration{
+					TokenExpiration:    time.Minute,
+					NonceConfiguration: config.NonceConfiguration{MaxOutstanding: 3},
+				},
+				nil,
+				mockTokensRepository,
+				nil,
+				nil,
+				nil,
+				logrus.New(),
+			)
+
+			mockTokensRepository.On("CountOutstandingTokens", mock.Anything, "127.0.0.1").
+				Return(tt.byIP, nil).Once()
+			if tt.err == nil {
+				mockTokensRepository.On("CreateToken", mock.Anything, mock.MatchedBy(func(token *model.Token) bool {
+					return token.IP == "127.0.0.1"
+				})).Return(func(_ context.Context, token *model.Token) (*model.Token, error) {
+					return token, nil
+				}).Once()
+			}
+
+			token, err := app.CreateToken(context.Background(), &model.CreateTokenInput{
+				Address: "userAddress",
+				Action:  model.ACTION_CREATE_ASSET,
+				IP:      "127.0.0.1",
+			})
+
+			assert.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.NotNil(t, token)
+			} else {
+				assert.Nil(t, token)
+			}
+			mockTokensRepository.AssertExpectations(t)
+		})
+	}
+}
+func TestAssetsApp_CreateToken_BindsAddressAndRoute(t *testing.T) {
+	mockTokensRepository := new(tokensMock.Repository)
+	mockLogger := logrus.New()
+
+	app := app.NewAssetsApp(
+		&config.Configuration{TokenExpiration: 1 * time.Hour},
+		nil,
+		mockTokensRepository,
+		nil,
+		nil,
+		nil,
+		mockLogger,
+	)
+
+	assetID := "asset123"
+	mockTokensRepository.On("CreateToken", mock.Anything, mock.MatchedBy(func(token *model.Token) bool {
+		return token.Address == "userAddress" &&
+			token.Method == "PUT" &&
+			token.Path == "/assets/asset123" &&
+			token.AssetID != nil && *token.AssetID == assetID
+	})).Return(func(_ context.Context, token *model.Token) (*model.Token, error) {
+		return token, nil
+	}).Once()
+
+	token, err := app.CreateToken(context.Background(), &model.CreateTokenInput{
+		Address: "userAddress",
+		Action:  model.ACTION_UPDATE_ASSET,
+		AssetID: &assetID,
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, token)
+	assert.True(t, token.IsIssuedFor("userAddress", "PUT", "/assets/asset123"))
+	assert.False(t, token.IsIssuedFor("otherAddress", "PUT", "/assets/asset123"))
+	assert.False(t, token.IsIssuedFor("userAddress", "DELETE", "/assets/asset123"))
+
+	mockTokensRepository.AssertExpectations(t)
+}
+func TestAssetsApp_CreateToken_SIWS(t *testing.T) {
+	mockTokensRepository := new(tokensMock.Repository)
+	mockLogger := logrus.New()
+
+	cfg := &config.Configuration{TokenExpiration: 1 * time.Hour}
+	cfg.AuthConfiguration.Domain = "assetportal.xyz"
+	cfg.AuthConfiguration.URI = "https://assetportal.xyz"
+	app := app.NewAssetsApp(cfg, nil, mockTokensRepository, nil, nil, nil, mockLogger)
+
+	mockTokensRepository.On("CreateToken", mock.Anything, mock.AnythingOfType("*model.Token")).
+		Return(func(_ context.Context, token *model.Token) (*model.Token, error) {
+			return token, nil
+		}).Once()
+
+	format := model.FORMAT_SIWS
+	token, err := app.CreateToken(context.Background(), &model.CreateTokenInput{
+		Address: "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5",
+		Action:  model.ACTION_CREATE_ASSET,
+		Format:  &format,
+	})
+
+	assert.NoError(t, err)
+	message, err := model.ParseSIWSMessage(token.Message)
+	assert.NoError(t, err)
+	assert.Equal(t, "assetportal.xyz", message.Domain)
+	assert.Equal(t, model.POLKADOT, message.ChainID)
+	assert.Equal(t, token.Token, message.Nonce)
+	assert.Equal(t, "Create a new asset.", message.Statement)
+
+	mockTokensRepository.AssertExpectations(t)
+}
+
+func TestAssetsApp_CreateToken_SIWSDisabled(t *testing.T) {
+	mockTokensRepository := new(tokensMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, mockTokensRepository, nil, nil, nil, logrus.New())
+
+	format := model.FORMAT_SIWS
+	token, err := app.CreateToken(context.Background(), &model.CreateTokenInput{
+		Address: "userAddress",
+		Action:  model.ACTION_CREATE_ASSET,
+		Format:  &format,
+	})
+
+	assert.Nil(t, token)
+	assert.Equal(t, appError.ErrSIWSDisabled, err)
+	mockTokensRepository.AssertExpectations(t)
+}
+
 func TestAssetsApp_CreateAsset_Success(t *testing.T) {
 	mockAssetsRepository := new(assetsMock.Repository)
 	mockLogger := logrus.New()
@@ -148,6 +314,7 @@ func TestAssetsApp_CreateAsset_Success(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
@@ -155,10 +322,13 @@ func TestAssetsApp_CreateAsset_Success(t *testing.T) {
 		ID: "mockedAsset123",
 	}
 
+	runInTx(mockAssetsRepository)
 	mockAssetsRepository.On("CreateAsset", mock.Anything, mock.AnythingOfType("*model.Asset")).
 		Return(expectedAsset, nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, revisionOf("mockedAsset123", model.REVISION_CREATE, "userAddress")).
+		Return(nil).Once()
 
-	asset, err := app.CreateAsset(context.Background(), expectedAsset)
+	asset, err := app.CreateAsset(context.Background(), expectedAsset, signedBy("userAddress"))
 
 	assert.NoError(t, err)
 	assert.NotNil(t, asset)
@@ -166,6 +336,49 @@ func TestAssetsApp_CreateAsset_Success(t *testing.T) {
 
 	mockAssetsRepository.AssertExpectations(t)
 }
+func TestAssetsApp_CreateAsset_AddressNotOnBlockchain(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+
+	app := app.NewAssetsApp(
+		&config.Configuration{},
+		nil,
+		nil,
+		mockAssetsRepository,
+		nil,
+		nil,
+		logrus.New(),
+	)
+
+	kusama := model.KUSAMA
+	polkadot := uint16(0)
+	signer := signedBy("userAddress")
+	signer.SS58Prefix = &polkadot
+
+	asset, err := app.CreateAsset(context.Background(), &model.Asset{ID: "mockedAsset123", Blockchain: &kusama}, signer)
+
+	assert.Equal(t, appError.ErrAddressNotOnBlockchain, err)
+	assert.Nil(t, asset)
+	mockAssetsRepository.AssertNotCalled(t, "CreateAsset", mock.Anything, mock.Anything)
+}
+func TestAssetsApp_CreateAsset_InvalidBlockchain(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	networks, err := model.NewNetworks([]model.Network{
+		{Name: model.POLKADOT, SS58Prefix: 0, DisplayName: "Polkadot", Enabled: true},
+		{Name: model.KUSAMA, SS58Prefix: 2, DisplayName: "Kusama"},
+	})
+	require.NoError(t, err)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New()).WithNetworks(networks)
+
+	// Kusama is disabled and Westend is not in the registry.
+	for _, blockchain := range []string{model.KUSAMA, "westend"} {
+		asset, err := app.CreateAsset(context.Background(), &model.Asset{ID: "mockedAsset123", Blockchain: &blockchain}, signedBy("userAddress"))
+
+		assert.ErrorIs(t, err, appError.ErrInvalidBlockchain, blockchain)
+		assert.ErrorContains(t, err, "blockchain must be one of: polkadot", blockchain)
+		assert.Nil(t, asset)
+	}
+	mockAssetsRepository.AssertNotCalled(t, "CreateAsset", mock.Anything, mock.Anything)
+}
 func TestAssetsApp_CreateAsset_AssetIDExists(t *testing.T) {
 	mockAssetsRepository := new(assetsMock.Repository)
 	mockLogger := logrus.New()
@@ -176,6 +389,7 @@ func TestAssetsApp_CreateAsset_AssetIDExists(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
@@ -183,10 +397,11 @@ func TestAssetsApp_CreateAsset_AssetIDExists(t *testing.T) {
 		ID: "mockedAsset123",
 	}
 
+	runInTx(mockAssetsRepository)
 	mockAssetsRepository.On("CreateAsset", mock.Anything, mock.AnythingOfType("*model.Asset")).
 		Return(nil, fmt.Errorf("duplicate key value violates unique constraint \"assets_id_key\"")).Once()
 
-	asset, err := app.CreateAsset(context.Background(), expectedAsset)
+	asset, err := app.CreateAsset(context.Background(), expectedAsset, signedBy("userAddress"))
 
 	assert.Error(t, err)
 	assert.Nil(t, asset)
@@ -204,6 +419,7 @@ func TestAssetsApp_CreateAsset_Failure(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
@@ -211,10 +427,11 @@ func TestAssetsApp_CreateAsset_Failure(t *testing.T) {
 		ID: "mockedAsset123",
 	}
 
+	runInTx(mockAssetsRepository)
 	mockAssetsRepository.On("CreateAsset", mock.Anything, mock.AnythingOfType("*model.Asset")).
 		Return(nil, fmt.Errorf("some unknown error")).Once()
 
-	asset, err := app.CreateAsset(context.Background(), expectedAsset)
+	asset, err := app.CreateAsset(context.Background(), expectedAsset, signedBy("userAddress"))
 
 	assert.Error(t, err)
 	assert.Nil(t, asset)
@@ -232,6 +449,7 @@ func TestAssetsApp_CreateAsset_InvalidAsset(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
@@ -239,10 +457,11 @@ func TestAssetsApp_CreateAsset_InvalidAsset(t *testing.T) {
 		ID: "mockedAsset123",
 	}
 
+	runInTx(mockAssetsRepository)
 	mockAssetsRepository.On("CreateAsset", mock.Anything, invalidAsset).
 		Return(nil, fmt.Errorf("invalid asset data")).Once()
 
-	asset, err := app.CreateAsset(context.Background(), invalidAsset)
+	asset, err := app.CreateAsset(context.Background(), invalidAsset, signedBy("userAddress"))
 
 	assert.Error(t, err)
 	assert.Nil(t, asset)
@@ -260,6 +479,7 @@ func TestAssetsApp_GetAssetByID_Success(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
@@ -270,7 +490,7 @@ func TestAssetsApp_GetAssetByID_Success(t *testing.T) {
 	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
 		Return(expectedAsset, nil).Once()
 
-	asset, err := app.GetAssetByID(context.Background(), "asset123")
+	asset, err := app.GetAssetByID(context.Background(), "asset123", nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, asset)
@@ -278,6 +498,39 @@ func TestAssetsApp_GetAssetByID_Success(t *testing.T) {
 
 	mockAssetsRepository.AssertExpectations(t)
 }
+func TestAssetsApp_GetAssetByID_Fields(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	mockLogger := logrus.New()
+
+	app := app.NewAssetsApp(
+		&config.Configuration{},
+		nil,
+		nil,
+		mockAssetsRepository,
+		nil,
+		nil,
+		mockLogger,
+	)
+
+	fields := []string{"id", "image"}
+	// The version is read along with the fields, as the ETag.
+	mockAssetsRepository.On("GetAssets", mock.Anything, mock.MatchedBy(func(filters *model.GetAssetsInput) bool {
+		return *filters.ID == "asset123" && assert.ObjectsAreEqual([]string{"id", "image", "version"}, filters.Projection)
+	})).Return([]*model.Asset{{ID: "asset123"}}, nil).Once()
+	mockAssetsRepository.On("GetAssets", mock.Anything, mock.AnythingOfType("*model.GetAssetsInput")).
+		Return([]*model.Asset{}, nil).Once()
+
+	asset, err := app.GetAssetByID(context.Background(), "asset123", fields)
+	assert.NoError(t, err)
+	assert.Equal(t, "asset123", asset.ID)
+
+	asset, err = app.GetAssetByID(context.Background(), "missing", fields)
+	assert.NoError(t, err)
+	assert.Nil(t, asset)
+	assert.Equal(t, []string{"id", "image"}, fields)
+
+	mockAssetsRepository.AssertExpectations(t)
+}
 func TestAssetsApp_GetAssetByID_Failure(t *testing.T) {
 	mockAssetsRepository := new(assetsMock.Repository)
 	mockLogger := logrus.New()
@@ -288,13 +541,14 @@ func TestAssetsApp_GetAssetByID_Failure(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
 	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
 		Return(nil, fmt.Errorf("database error")).Once()
 
-	asset, err := app.GetAssetByID(context.Background(), "asset123")
+	asset, err := app.GetAssetByID(context.Background(), "asset123", nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, asset)
@@ -302,6 +556,57 @@ func TestAssetsApp_GetAssetByID_Failure(t *testing.T) {
 
 	mockAssetsRepository.AssertExpectations(t)
 }
+func TestAssetsApp_GetAssetsByIDs(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	mockLogger := logrus.New()
+
+	app := app.NewAssetsApp(
+		&config.Configuration{},
+		nil,
+		nil,
+		mockAssetsRepository,
+		nil,
+		nil,
+		mockLogger,
+	)
+
+	ids := []string{"asset123", "asset456"}
+	mockAssetsRepository.On("GetAssetsByIDs", mock.Anything, ids, []string(nil)).
+		Return([]*model.Asset{{ID: "asset456"}}, nil).Once()
+
+	batch, err := app.GetAssetsByIDs(context.Background(), ids, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, batch.Assets, 1)
+	assert.Contains(t, batch.Assets, "asset456")
+	assert.Equal(t, []string{"asset123"}, batch.Missing)
+
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_GetAssetsByIDs_Failure(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	mockLogger := logrus.New()
+
+	app := app.NewAssetsApp(
+		&config.Configuration{},
+		nil,
+		nil,
+		mockAssetsRepository,
+		nil,
+		nil,
+		mockLogger,
+	)
+
+	mockAssetsRepository.On("GetAssetsByIDs", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("database error")).Once()
+
+	batch, err := app.GetAssetsByIDs(context.Background(), []string{"asset123"}, nil)
+
+	assert.Nil(t, batch)
+	assert.Equal(t, appError.ErrGettingAsset, err)
+
+	mockAssetsRepository.AssertExpectations(t)
+}
 func TestAssetsApp_GetAssets_Success(t *testing.T) {
 	mockAssetsRepository := new(assetsMock.Repository)
 	mockLogger := logrus.New()
@@ -312,6 +617,7 @@ func TestAssetsApp_GetAssets_Success(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
@@ -323,7 +629,7 @@ func TestAssetsApp_GetAssets_Success(t *testing.T) {
 	mockAssetsRepository.On("GetAssets", mock.Anything, mock.AnythingOfType("*model.GetAssetsInput")).
 		Return(expectedAssets, nil).Once()
 
-	assets, err := app.GetAssets(context.Background(), &model.GetAssetsInput{})
+	assets, _, err := app.GetAssets(context.Background(), &model.GetAssetsInput{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, assets)
@@ -333,6 +639,180 @@ func TestAssetsApp_GetAssets_Success(t *testing.T) {
 
 	mockAssetsRepository.AssertExpectations(t)
 }
+func TestAssetsApp_GetAssets_NextCursor(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	mockLogger := logrus.New()
+
+	app := app.NewAssetsApp(
+		&config.Configuration{},
+		nil,
+		nil,
+		mockAssetsRepository,
+		nil,
+		nil,
+		mockLogger,
+	)
+
+	first, second, third := 1, 2, 3
+	limit := 2
+	mockAssetsRepository.On("GetAssets", mock.Anything, mock.MatchedBy(func(filters *model.GetAssetsInput) bool {
+		return filters.Limit != nil && *filters.Limit == limit+1
+	})).Return([]*model.Asset{
+		{ID_: &first, ID: "asset1"},
+		{ID_: &second, ID: "asset2"},
+		{ID_: &third, ID: "asset3"},
+	}, nil).Once()
+
+	sort := []model.OrderKey{{Field: "_id", Ascending: true}}
+	assets, meta, err := app.GetAssets(context.Background(), &model.GetAssetsInput{Sort: sort, Pagination: model.Pagination{Limit: &limit}})
+
+	assert.NoError(t, err)
+	assert.Len(t, assets, 2)
+	assert.Equal(t, 2, limit)
+	assert.True(t, meta.HasMore)
+	assert.Equal(t, limit, meta.Limit)
+	assert.Nil(t, meta.Total)
+	cursor, err := model.DecodeCursor(meta.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2"}, cursor.Values)
+
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_GetAssets_LastPage(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	mockLogger := logrus.New()
+
+	app := app.NewAssetsApp(
+		&config.Configuration{},
+		nil,
+		nil,
+		mockAssetsRepository,
+		nil,
+		nil,
+		mockLogger,
+	)
+
+	first := 1
+	limit := 2
+	mockAssetsRepository.On("GetAssets", mock.Anything, mock.AnythingOfType("*model.GetAssetsInput")).
+		Return([]*model.Asset{{ID_: &first, ID: "asset1"}}, nil).Once()
+
+	assets, meta, err := app.GetAssets(context.Background(), &model.GetAssetsInput{Pagination: model.Pagination{Limit: &limit}})
+
+	assert.NoError(t, err)
+	assert.Len(t, assets, 1)
+	assert.False(t, meta.HasMore)
+	assert.Empty(t, meta.NextCursor)
+
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_GetAssets_InvalidBlockchain(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	networks, err := model.NewNetworks([]model.Network{
+		{Name: model.POLKADOT, SS58Prefix: 0, DisplayName: "Polkadot", Enabled: true},
+		{Name: model.KUSAMA, SS58Prefix: 2, DisplayName: "Kusama"},
+	})
+	require.NoError(t, err)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New()).WithNetworks(networks)
+
+	_, _, err = app.GetAssets(context.Background(), &model.GetAssetsInput{Blockchain: []string{model.POLKADOT, "westend"}})
+	assert.ErrorIs(t, err, appError.ErrInvalidBlockchain)
+	mockAssetsRepository.AssertNotCalled(t, "GetAssets", mock.Anything, mock.Anything)
+
+	// Assets on disabled networks can still be listed.
+	mockAssetsRepository.On("GetAssets", mock.Anything, mock.Anything).Return([]*model.Asset{}, nil).Once()
+	_, _, err = app.GetAssets(context.Background(), &model.GetAssetsInput{Blockchain: []string{model.KUSAMA}})
+	assert.NoError(t, err)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_GetAssets_RankedHasNoCursor(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	mockLogger := logrus.New()
+
+	app := app.NewAssetsApp(
+		&config.Configuration{},
+		nil,
+		nil,
+		mockAssetsRepository,
+		nil,
+		nil,
+		mockLogger,
+	)
+
+	first, second := 1, 2
+	limit, q := 1, "dot"
+	mockAssetsRepository.On("GetAssets", mock.Anything, mock.AnythingOfType("*model.GetAssetsInput")).
+		Return([]*model.Asset{{ID_: &first, ID: "asset1"}, {ID_: &second, ID: "asset2"}}, nil).Once()
+
+	sort := []model.OrderKey{{Field: "_id", Ascending: true}}
+	assets, meta, err := app.GetAssets(context.Background(), &model.GetAssetsInput{Q: &q, Sort: sort, Pagination: model.Pagination{Limit: &limit}})
+
+	assert.NoError(t, err)
+	assert.Len(t, assets, 1)
+	assert.True(t, meta.HasMore)
+	assert.Empty(t, meta.NextCursor)
+
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_GetAssets_Count(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	mockLogger := logrus.New()
+
+	app := app.NewAssetsApp(
+		&config.Configuration{},
+		nil,
+		nil,
+		mockAssetsRepository,
+		nil,
+		nil,
+		mockLogger,
+	)
+
+	first := 1
+	limit, offset, count := 10, 20, true
+	filters := &model.GetAssetsInput{Count: &count, Pagination: model.Pagination{Limit: &limit, Offset: &offset}}
+	mockAssetsRepository.On("GetAssets", mock.Anything, mock.AnythingOfType("*model.GetAssetsInput")).
+		Return([]*model.Asset{{ID_: &first, ID: "asset1"}}, nil).Once()
+	mockAssetsRepository.On("CountAssets", mock.Anything, filters).Return(21, nil).Once()
+
+	_, meta, err := app.GetAssets(context.Background(), filters)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 21, *meta.Total)
+	assert.Equal(t, limit, meta.Limit)
+	assert.Equal(t, offset, meta.Offset)
+	assert.False(t, meta.HasMore)
+
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_GetAssets_CountFailure(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	mockLogger := logrus.New()
+
+	app := app.NewAssetsApp(
+		&config.Configuration{},
+		nil,
+		nil,
+		mockAssetsRepository,
+		nil,
+		nil,
+		mockLogger,
+	)
+
+	count := true
+	mockAssetsRepository.On("GetAssets", mock.Anything, mock.AnythingOfType("*model.GetAssetsInput")).
+		Return([]*model.Asset{}, nil).Once()
+	mockAssetsRepository.On("CountAssets", mock.Anything, mock.AnythingOfType("*model.GetAssetsInput")).
+		Return(0, fmt.Errorf("database error")).Once()
+
+	assets, meta, err := app.GetAssets(context.Background(), &model.GetAssetsInput{Count: &count})
+
+	assert.Equal(t, appError.ErrGettingAsset, err)
+	assert.Nil(t, assets)
+	assert.Nil(t, meta)
+
+	mockAssetsRepository.AssertExpectations(t)
+}
 func TestAssetsApp_GetAssets_Failure(t *testing.T) {
 	mockAssetsRepository := new(assetsMock.Repository)
 	mockLogger := logrus.New()
@@ -343,13 +823,14 @@ func TestAssetsApp_GetAssets_Failure(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
 	mockAssetsRepository.On("GetAssets", mock.Anything, mock.AnythingOfType("*model.GetAssetsInput")).
 		Return(nil, fmt.Errorf("database error")).Once()
 
-	assets, err := app.GetAssets(context.Background(), &model.GetAssetsInput{})
+	assets, _, err := app.GetAssets(context.Background(), &model.GetAssetsInput{})
 
 	assert.Error(t, err)
 	assert.Nil(t, assets)
@@ -367,6 +848,7 @@ func TestAssetsApp_UpdateAsset_Success(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
@@ -374,10 +856,15 @@ func TestAssetsApp_UpdateAsset_Success(t *testing.T) {
 		ID: "asset123",
 	}
 
-	mockAssetsRepository.On("UpdateAsset", mock.Anything, assetToUpdate).
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+		Return(&model.Asset{ID: "asset123", Address: "userAddress"}, nil).Once()
+	mockAssetsRepository.On("UpdateAsset", mock.Anything, assetToUpdate, (*int)(nil)).
+		Return(nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, revisionOf("asset123", model.REVISION_UPDATE, "userAddress")).
 		Return(nil).Once()
 
-	err := app.UpdateAsset(context.Background(), assetToUpdate)
+	err := app.UpdateAsset(context.Background(), assetToUpdate, nil, signedBy("userAddress"))
 
 	assert.NoError(t, err)
 
@@ -393,6 +880,7 @@ func TestAssetsApp_UpdateAsset_AssetDoesNotBelongToUser(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
@@ -400,10 +888,13 @@ func TestAssetsApp_UpdateAsset_AssetDoesNotBelongToUser(t *testing.T) {
 		ID: "asset123",
 	}
 
-	mockAssetsRepository.On("UpdateAsset", mock.Anything, assetToUpdate).
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+		Return(&model.Asset{ID: "asset123", Address: "userAddress"}, nil).Once()
+	mockAssetsRepository.On("UpdateAsset", mock.Anything, assetToUpdate, (*int)(nil)).
 		Return(fmt.Errorf("does not exist")).Once()
 
-	err := app.UpdateAsset(context.Background(), assetToUpdate)
+	err := app.UpdateAsset(context.Background(), assetToUpdate, nil, signedBy("userAddress"))
 
 	assert.Error(t, err)
 	assert.Equal(t, appError.ErrAssetDoesNotBelongToTheUser, err)
@@ -420,6 +911,7 @@ func TestAssetsApp_UpdateAsset_Failure(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
@@ -427,10 +919,13 @@ func TestAssetsApp_UpdateAsset_Failure(t *testing.T) {
 		ID: "asset123",
 	}
 
-	mockAssetsRepository.On("UpdateAsset", mock.Anything, assetToUpdate).
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+		Return(&model.Asset{ID: "asset123", Address: "userAddress"}, nil).Once()
+	mockAssetsRepository.On("UpdateAsset", mock.Anything, assetToUpdate, (*int)(nil)).
 		Return(fmt.Errorf("general update error")).Once()
 
-	err := app.UpdateAsset(context.Background(), assetToUpdate)
+	err := app.UpdateAsset(context.Background(), assetToUpdate, nil, signedBy("userAddress"))
 
 	assert.Error(t, err)
 	assert.Equal(t, appError.ErrUpdatingAsset, err)
@@ -447,16 +942,22 @@ func TestAssetsApp_DeleteAsset_Success(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
 	assetID := "asset123"
 	address := "userAddress"
 
-	mockAssetsRepository.On("DeleteAsset", mock.Anything, assetID, address).
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, assetID).
+		Return(&model.Asset{ID: assetID, Address: address}, nil).Once()
+	mockAssetsRepository.On("DeleteAsset", mock.Anything, assetID, address, (*int)(nil)).
+		Return(nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, revisionOf(assetID, model.REVISION_DELETE, address)).
 		Return(nil).Once()
 
-	err := app.DeleteAsset(context.Background(), assetID, address)
+	err := app.DeleteAsset(context.Background(), assetID, nil, signedBy(address))
 
 	assert.NoError(t, err)
 
@@ -472,16 +973,20 @@ func TestAssetsApp_DeleteAsset_AssetDoesNotBelongToUser(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
 	assetID := "asset123"
 	address := "userAddress"
 
-	mockAssetsRepository.On("DeleteAsset", mock.Anything, assetID, address).
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, assetID).
+		Return(&model.Asset{ID: assetID, Address: address}, nil).Once()
+	mockAssetsRepository.On("DeleteAsset", mock.Anything, assetID, address, (*int)(nil)).
 		Return(fmt.Errorf("does not exist")).Once()
 
-	err := app.DeleteAsset(context.Background(), assetID, address)
+	err := app.DeleteAsset(context.Background(), assetID, nil, signedBy(address))
 
 	assert.Error(t, err)
 	assert.Equal(t, appError.ErrAssetDoesNotBelongToTheUser, err)
@@ -498,19 +1003,380 @@ func TestAssetsApp_DeleteAsset_Failure(t *testing.T) {
 		nil,
 		mockAssetsRepository,
 		nil,
+		nil,
 		mockLogger,
 	)
 
 	assetID := "asset123"
 	address := "userAddress"
 
-	mockAssetsRepository.On("DeleteAsset", mock.Anything, assetID, address).
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, assetID).
+		Return(&model.Asset{ID: assetID, Address: address}, nil).Once()
+	mockAssetsRepository.On("DeleteAsset", mock.Anything, assetID, address, (*int)(nil)).
 		Return(fmt.Errorf("general deletion error")).Once()
 
-	err := app.DeleteAsset(context.Background(), assetID, address)
+	err := app.DeleteAsset(context.Background(), assetID, nil, signedBy(address))
 
 	assert.Error(t, err)
 	assert.Equal(t, appError.ErrDeletingAsset, err)
 
 	mockAssetsRepository.AssertExpectations(t)
 }
+
+func TestAssetsApp_UpdateAsset_Delegates(t *testing.T) {
+	tests := []struct {
+		name     string
+		delegate *model.Delegate
+		err      error
+	}{
+		{name: "member", delegate: &model.Delegate{Owner: "ownerAddress", Address: "delegateAddress", Role: model.ROLE_MEMBER}},
+		{name: "proxy", delegate: &model.Delegate{Owner: "ownerAddress", Address: "delegateAddress", Role: model.ROLE_PROXY}},
+		{name: "not a delegate", err: appError.ErrAssetDoesNotBelongToTheUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAssetsRepository := new(assetsMock.Repository)
+			mockDelegatesRepository := new(delegatesMock.Repository)
+			app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, mockDelegatesRepository, nil, logrus.New())
+
+			runInTx(mockAssetsRepository)
+			mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+				Return(&model.Asset{ID: "asset123", Address: "ownerAddress"}, nil).Once()
+			mockDelegatesRepository.On("GetDelegate", mock.Anything, "ownerAddress", "delegateAddress").
+				Return(tt.delegate, nil).Once()
+			if tt.err == nil {
+				// The update must still be scoped to the owner, not to the delegate.
+				mockAssetsRepository.On("UpdateAsset", mock.Anything, mock.MatchedBy(func(asset *model.Asset) bool {
+					return asset.Address == "ownerAddress"
+				}), (*int)(nil)).Return(nil).Once()
+				// The revision records the delegate who made the change.
+				mockAssetsRepository.On("CreateRevision", mock.Anything, revisionOf("asset123", model.REVISION_UPDATE, "delegateAddress")).
+					Return(nil).Once()
+			}
+
+			err := app.UpdateAsset(context.Background(), &model.Asset{ID: "asset123"}, nil, signedBy("delegateAddress"))
+
+			assert.Equal(t, tt.err, err)
+			mockAssetsRepository.AssertExpectations(t)
+			mockDelegatesRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAssetsApp_DeleteAsset_Delegates(t *testing.T) {
+	tests := []struct {
+		name string
+		role string
+		err  error
+	}{
+		{name: "co-owner", role: model.ROLE_CO_OWNER},
+		{name: "member cannot delete", role: model.ROLE_MEMBER, err: appError.ErrAssetDoesNotBelongToTheUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAssetsRepository := new(assetsMock.Repository)
+			mockDelegatesRepository := new(delegatesMock.Repository)
+			app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, mockDelegatesRepository, nil, logrus.New())
+
+			runInTx(mockAssetsRepository)
+			mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+				Return(&model.Asset{ID: "asset123", Address: "ownerAddress"}, nil).Once()
+			mockDelegatesRepository.On("GetDelegate", mock.Anything, "ownerAddress", "delegateAddress").
+				Return(&model.Delegate{Owner: "ownerAddress", Address: "delegateAddress", Role: tt.role}, nil).Once()
+			if tt.err == nil {
+				mockAssetsRepository.On("DeleteAsset", mock.Anything, "asset123", "ownerAddress", (*int)(nil)).Return(nil).Once()
+				mockAssetsRepository.On("CreateRevision", mock.Anything, revisionOf("asset123", model.REVISION_DELETE, "delegateAddress")).
+					Return(nil).Once()
+			}
+
+			err := app.DeleteAsset(context.Background(), "asset123", nil, signedBy("delegateAddress"))
+
+			assert.Equal(t, tt.err, err)
+			mockAssetsRepository.AssertExpectations(t)
+			mockDelegatesRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAssetsApp_DeleteAsset_NotFound(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").Return(nil, nil).Once()
+
+	err := app.DeleteAsset(context.Background(), "asset123", nil, signedBy("userAddress"))
+
+	assert.Equal(t, appError.ErrAssetDoesNotBelongToTheUser, err)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_UpdateAsset_StaleVersion(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+		Return(&model.Asset{ID: "asset123", Address: "userAddress", Version: 3}, nil).Once()
+
+	version := 2
+	err := app.UpdateAsset(context.Background(), &model.Asset{ID: "asset123"}, &version, signedBy("userAddress"))
+
+	assert.Equal(t, appError.ErrAssetVersionMismatch, err)
+	mockAssetsRepository.AssertNotCalled(t, "UpdateAsset", mock.Anything, mock.Anything, mock.Anything)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_UpdateAsset_AddressNotOnBlockchain(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	polkadot, kusama := model.POLKADOT, model.KUSAMA
+	polkadotPrefix, kusamaPrefix := uint16(0), uint16(2)
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+		Return(&model.Asset{ID: "asset123", Address: "userAddress", Blockchain: &polkadot}, nil).Twice()
+
+	// The asset cannot be moved to Kusama with a Polkadot address.
+	signer := signedBy("userAddress")
+	signer.SS58Prefix = &polkadotPrefix
+	err := app.UpdateAsset(context.Background(), &model.Asset{ID: "asset123", Blockchain: &kusama}, nil, signer)
+
+	assert.Equal(t, appError.ErrAddressNotOnBlockchain, err)
+	mockAssetsRepository.AssertNotCalled(t, "UpdateAsset", mock.Anything, mock.Anything, mock.Anything)
+
+	// The asset stays on its blockchain, whatever network the address is encoded for.
+	mockAssetsRepository.On("UpdateAsset", mock.Anything, mock.AnythingOfType("*model.Asset"), (*int)(nil)).Return(nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, revisionOf("asset123", model.REVISION_UPDATE, "userAddress")).Return(nil).Once()
+	signer.SS58Prefix = &kusamaPrefix
+	err = app.UpdateAsset(context.Background(), &model.Asset{ID: "asset123", Blockchain: &polkadot}, nil, signer)
+
+	assert.NoError(t, err)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_DeleteAsset_ConcurrentWrite(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	version := 3
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+		Return(&model.Asset{ID: "asset123", Address: "userAddress", Version: 3}, nil).Once()
+	// Another write got the asset to version 4 before the delete.
+	mockAssetsRepository.On("DeleteAsset", mock.Anything, "asset123", "userAddress", &version).
+		Return(fmt.Errorf("asset with id 'asset123' and address 'userAddress' does not exist or is not at version 3")).Once()
+
+	err := app.DeleteAsset(context.Background(), "asset123", &version, signedBy("userAddress"))
+
+	assert.Equal(t, appError.ErrAssetVersionMismatch, err)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_PatchAsset_Success(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	blockchain, description := model.POLKADOT, "description"
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+		Return(&model.Asset{ID: "asset123", Address: "userAddress", Blockchain: &blockchain, Description: &description, Version: 2}, nil).Once()
+	version := 2
+	mockAssetsRepository.On("PatchAsset", mock.Anything, mock.MatchedBy(func(asset *model.Asset) bool {
+		return asset.Description == nil && asset.Address == "userAddress" && *asset.Blockchain == model.POLKADOT
+	}), &version).Return(nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, revisionOf("asset123", model.REVISION_UPDATE, "userAddress")).
+		Return(nil).Once()
+
+	asset, err := app.PatchAsset(context.Background(), "asset123", model.MERGE_PATCH, json.RawMessage(`{"description": null}`), &version, signedBy("userAddress"))
+
+	require.NoError(t, err)
+	assert.Nil(t, asset.Description)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_PatchAsset_InvalidPatch(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	blockchain := model.POLKADOT
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+		Return(&model.Asset{ID: "asset123", Address: "userAddress", Blockchain: &blockchain}, nil).Once()
+
+	_, err := app.PatchAsset(context.Background(), "asset123", model.JSON_PATCH, json.RawMessage(`[{"op": "remove", "path": "/image"}]`), nil, signedBy("userAddress"))
+
+	assert.ErrorIs(t, err, appError.ErrInvalidPatch)
+	mockAssetsRepository.AssertNotCalled(t, "PatchAsset", mock.Anything, mock.Anything, mock.Anything)
+}
+func TestAssetsApp_PatchAsset_AddressNotOnBlockchain(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	blockchain := model.POLKADOT
+	prefix := uint16(0)
+	signer := signedBy("userAddress")
+	signer.SS58Prefix = &prefix
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+		Return(&model.Asset{ID: "asset123", Address: "userAddress", Blockchain: &blockchain}, nil).Once()
+
+	_, err := app.PatchAsset(context.Background(), "asset123", model.MERGE_PATCH, json.RawMessage(`{"blockchain": "kusama"}`), nil, signer)
+
+	assert.Equal(t, appError.ErrAddressNotOnBlockchain, err)
+	mockAssetsRepository.AssertNotCalled(t, "PatchAsset", mock.Anything, mock.Anything, mock.Anything)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_PatchAsset_InvalidBlockchain(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	networks, err := model.NewNetworks([]model.Network{
+		{Name: model.POLKADOT, SS58Prefix: 0, DisplayName: "Polkadot", Enabled: true},
+		{Name: model.KUSAMA, SS58Prefix: 2, DisplayName: "Kusama"},
+	})
+	require.NoError(t, err)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New()).WithNetworks(networks)
+
+	blockchain := model.POLKADOT
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+		Return(&model.Asset{ID: "asset123", Address: "userAddress", Blockchain: &blockchain}, nil).Once()
+
+	_, err = app.PatchAsset(context.Background(), "asset123", model.MERGE_PATCH, json.RawMessage(`{"blockchain": "kusama"}`), nil, signedBy("userAddress"))
+
+	assert.ErrorIs(t, err, appError.ErrInvalidBlockchain)
+	mockAssetsRepository.AssertNotCalled(t, "PatchAsset", mock.Anything, mock.Anything, mock.Anything)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_RestoreAsset_Success(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	cfg := &config.Configuration{AssetsConfiguration: config.AssetsConfiguration{RestoreWindow: time.Hour}}
+	app := app.NewAssetsApp(cfg, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	window := mock.MatchedBy(func(deletedAfter time.Time) bool {
+		return time.Until(deletedAfter) < -59*time.Minute
+	})
+	asset := &model.Asset{ID: "asset123", Address: "userAddress"}
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("RestoreAsset", mock.Anything, "asset123", "userAddress", window).Return(asset, nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, revisionOf("asset123", model.REVISION_RESTORE, "userAddress")).
+		Return(nil).Once()
+
+	restored, err := app.RestoreAsset(context.Background(), "asset123", signedBy("userAddress"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, asset, restored)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_RestoreAsset_NotRestorable(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("RestoreAsset", mock.Anything, "asset123", "userAddress", mock.Anything).Return(nil, nil).Once()
+
+	restored, err := app.RestoreAsset(context.Background(), "asset123", signedBy("userAddress"))
+
+	assert.Nil(t, restored)
+	assert.Equal(t, appError.ErrAssetNotRestorable, err)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_RestoreAsset_Failure(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("RestoreAsset", mock.Anything, "asset123", "userAddress", mock.Anything).Return(nil, fmt.Errorf("db error")).Once()
+
+	restored, err := app.RestoreAsset(context.Background(), "asset123", signedBy("userAddress"))
+
+	assert.Nil(t, restored)
+	assert.Equal(t, appError.ErrRestoringAsset, err)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_CreateAsset_RevisionFailure(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("CreateAsset", mock.Anything, mock.AnythingOfType("*model.Asset")).
+		Return(&model.Asset{ID: "asset123"}, nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, mock.Anything).Return(fmt.Errorf("db error")).Once()
+
+	asset, err := app.CreateAsset(context.Background(), &model.Asset{ID: "asset123"}, signedBy("userAddress"))
+
+	assert.Nil(t, asset)
+	assert.Equal(t, appError.ErrRecordingRevision, err)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_GetAssetAsOf(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").Return(&model.Asset{ID: "asset123"}, nil).Twice()
+
+	number := 2
+	at := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	revision := &model.AssetRevision{AssetID: "asset123", Revision: 2}
+	mockAssetsRepository.On("GetRevision", mock.Anything, "asset123", 2).Return(revision, nil).Once()
+	mockAssetsRepository.On("GetRevisionAt", mock.Anything, "asset123", at).Return(nil, nil).Once()
+
+	found, err := app.GetAssetAsOf(context.Background(), &model.GetAssetAsOfInput{ID: "asset123", Revision: &number})
+	assert.NoError(t, err)
+	assert.Equal(t, revision, found)
+
+	found, err = app.GetAssetAsOf(context.Background(), &model.GetAssetAsOfInput{ID: "asset123", At: &at})
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_History_DeletedAsset(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	// Deleted assets are not found, like purged ones.
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").Return(nil, nil).Times(3)
+	limit, offset, number := 10, 0, 1
+
+	revisions, err := app.GetRevisions(context.Background(), "asset123", &model.Pagination{Limit: &limit, Offset: &offset})
+	assert.Nil(t, revisions)
+	assert.Equal(t, appError.ErrHistoryNotFound, err)
+
+	revision, err := app.GetAssetAsOf(context.Background(), &model.GetAssetAsOfInput{ID: "asset123", Revision: &number})
+	assert.Nil(t, revision)
+	assert.Equal(t, appError.ErrHistoryNotFound, err)
+
+	diff, err := app.DiffRevisions(context.Background(), "asset123", 1, 2)
+	assert.Nil(t, diff)
+	assert.Equal(t, appError.ErrHistoryNotFound, err)
+
+	mockAssetsRepository.AssertNotCalled(t, "GetRevisions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockAssetsRepository.AssertNotCalled(t, "GetRevision", mock.Anything, mock.Anything, mock.Anything)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_DiffRevisions(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").Return(&model.Asset{ID: "asset123"}, nil).Once()
+
+	before, after := "before", "after"
+	mockAssetsRepository.On("GetRevision", mock.Anything, "asset123", 1).
+		Return(&model.AssetRevision{AssetID: "asset123", Revision: 1, Snapshot: &model.Asset{ID: "asset123", Description: &before}}, nil).Once()
+	mockAssetsRepository.On("GetRevision", mock.Anything, "asset123", 2).
+		Return(&model.AssetRevision{AssetID: "asset123", Revision: 2, Snapshot: &model.Asset{ID: "asset123", Description: &after}}, nil).Once()
+
+	diff, err := app.DiffRevisions(context.Background(), "asset123", 1, 2)
+
+	assert.NoError(t, err)
+	assert.Len(t, diff.Changes, 1)
+	assert.Equal(t, "description", diff.Changes[0].Field)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_DiffRevisions_NotFound(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").Return(&model.Asset{ID: "asset123"}, nil).Once()
+
+	mockAssetsRepository.On("GetRevision", mock.Anything, "asset123", 1).Return(&model.AssetRevision{AssetID: "asset123", Revision: 1}, nil).Once()
+	mockAssetsRepository.On("GetRevision", mock.Anything, "asset123", 9).Return(nil, nil).Once()
+
+	diff, err := app.DiffRevisions(context.Background(), "asset123", 1, 9)
+
+	assert.Nil(t, diff)
+	assert.Equal(t, appError.ErrRevisionDoesNotExist, err)
+	mockAssetsRepository.AssertExpectations(t)
+}
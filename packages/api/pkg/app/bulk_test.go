@@ -0,0 +1,145 @@
+package app_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/AssetPortal/assets-api/pkg/adapters/assets"
+	assetsMock "github.com/AssetPortal/assets-api/pkg/adapters/assets/mocks"
+	"github.com/AssetPortal/assets-api/pkg/app"
+	"github.com/AssetPortal/assets-api/pkg/config"
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const bulkSigner = "userAddress"
+
+func bulkItems() []model.BulkItem {
+	polkadot := model.POLKADOT
+	return []model.BulkItem{
+		{Op: model.BULK_CREATE, ID: "new1", Blockchain: &polkadot},
+		{Op: model.BULK_UPDATE, ID: "other1", Blockchain: &polkadot},
+		{Op: model.BULK_DELETE, ID: "owned1"},
+	}
+}
+
+func TestAssetsApp_BulkAssets_BestEffort(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("CreateAsset", mock.Anything, mock.MatchedBy(func(asset *model.Asset) bool {
+		return asset.ID == "new1" && asset.Address == bulkSigner
+	})).Return(&model.Asset{ID: "new1", Address: bulkSigner}, nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, revisionOf("new1", model.REVISION_CREATE, bulkSigner)).Return(nil).Once()
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "other1").Return(nil, nil).Once()
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "owned1").
+		Return(&model.Asset{ID: "owned1", Address: bulkSigner}, nil).Once()
+	mockAssetsRepository.On("DeleteAsset", mock.Anything, "owned1", bulkSigner, (*int)(nil)).Return(nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, revisionOf("owned1", model.REVISION_DELETE, bulkSigner)).Return(nil).Once()
+
+	mode := model.BULK_MODE_BEST_EFFORT
+	results, err := app.BulkAssets(context.Background(), &model.BulkAssets{Mode: &mode, Items: bulkItems()}, signedBy(bulkSigner))
+
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "new1", results[0].Asset.ID)
+	assert.Equal(t, appError.ErrAssetDoesNotBelongToTheUser, results[1].Err)
+	assert.NoError(t, results[2].Err)
+	assert.Equal(t, 2, results[2].Index)
+	mockAssetsRepository.AssertExpectations(t)
+}
+
+func TestAssetsApp_BulkAssets_Atomic(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	items := bulkItems()[:1]
+	mockAssetsRepository.On("CreateAsset", mock.Anything, mock.AnythingOfType("*model.Asset")).
+		Return(&model.Asset{ID: "new1", Address: bulkSigner}, nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, revisionOf("new1", model.REVISION_CREATE, bulkSigner)).Return(nil).Once()
+
+	results, err := app.BulkAssets(context.Background(), &model.BulkAssets{Items: items}, signedBy(bulkSigner))
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	assert.NotNil(t, results[0].Asset)
+	mockAssetsRepository.AssertExpectations(t)
+}
+
+func TestAssetsApp_BulkAssets_AtomicRollback(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("CreateAsset", mock.Anything, mock.AnythingOfType("*model.Asset")).
+		Return(&model.Asset{ID: "new1", Address: bulkSigner}, nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, mock.Anything).Return(nil).Once()
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "other1").Return(nil, nil).Once()
+
+	results, err := app.BulkAssets(context.Background(), &model.BulkAssets{Items: bulkItems()}, signedBy(bulkSigner))
+
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, appError.ErrBulkRolledBack, results[0].Err)
+	assert.Nil(t, results[0].Asset)
+	assert.Equal(t, appError.ErrAssetDoesNotBelongToTheUser, results[1].Err)
+	assert.Equal(t, appError.ErrBulkRolledBack, results[2].Err)
+	mockAssetsRepository.AssertNotCalled(t, "DeleteAsset", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockAssetsRepository.AssertExpectations(t)
+}
+
+func TestAssetsApp_BulkAssets_AddressNotOnBlockchain(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	polkadot, kusama := model.POLKADOT, model.KUSAMA
+	prefix := uint16(0)
+	signer := signedBy(bulkSigner)
+	signer.SS58Prefix = &prefix
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "owned1").
+		Return(&model.Asset{ID: "owned1", Address: bulkSigner, Blockchain: &polkadot}, nil).Once()
+
+	mode := model.BULK_MODE_BEST_EFFORT
+	items := []model.BulkItem{{Op: model.BULK_UPDATE, ID: "owned1", Blockchain: &kusama}}
+	results, err := app.BulkAssets(context.Background(), &model.BulkAssets{Mode: &mode, Items: items}, signer)
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, appError.ErrAddressNotOnBlockchain, results[0].Err)
+	mockAssetsRepository.AssertNotCalled(t, "UpdateAsset", mock.Anything, mock.Anything, mock.Anything)
+	mockAssetsRepository.AssertExpectations(t)
+}
+
+func TestAssetsApp_BulkAssets_CommitFailure(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	// The batch cannot be committed, but the transaction of its item can.
+	mockAssetsRepository.On("RunInTx", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(ctx context.Context, repo assets.Repository) error) error {
+			if err := fn(ctx, mockAssetsRepository); err != nil {
+				return err
+			}
+			return fmt.Errorf("connection lost")
+		}).Once()
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("CreateAsset", mock.Anything, mock.AnythingOfType("*model.Asset")).
+		Return(&model.Asset{ID: "new1", Address: bulkSigner}, nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, mock.Anything).Return(nil).Once()
+
+	results, err := app.BulkAssets(context.Background(), &model.BulkAssets{Items: bulkItems()[:1]}, signedBy(bulkSigner))
+
+	assert.Nil(t, results)
+	assert.Equal(t, appError.ErrApplyingBulk, err)
+	mockAssetsRepository.AssertExpectations(t)
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/adapters/delegates"
+	"github.com/AssetPortal/assets-api/pkg/config"
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/sirupsen/logrus"
+)
+
+type DelegatesApp struct {
+	cfg                 *config.Configuration
+	delegatesRepository delegates.Repository
+	log                 *logrus.Logger
+}
+
+func NewDelegatesApp(
+	cfg *config.Configuration,
+	delegatesRepository delegates.Repository,
+	log *logrus.Logger,
+) *DelegatesApp {
+	return &DelegatesApp{
+		cfg:                 cfg,
+		delegatesRepository: delegatesRepository,
+		log:                 log,
+	}
+}
+
+// SetDelegate allows the address to act on the assets of the signer with the given role.
+// Delegates can update or delete the assets, so the signer must have signed a nonce.
+func (app *DelegatesApp) SetDelegate(ctx context.Context, signer *model.Principal, address, role string) (*model.Delegate, error) {
+	if signer.AuthMethod != model.AUTH_METHOD_SIGNATURE {
+		return nil, appError.ErrDelegateNotSigned
+	}
+	owner := signer.Address
+	if owner == address {
+		return nil, appError.ErrDelegateIsOwner
+	}
+	delegate, err := app.delegatesRepository.SetDelegate(ctx, &model.Delegate{
+		Owner:     owner,
+		Address:   address,
+		Role:      role,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		app.log.Errorf("error setting delegate '%s' of owner '%s': '%s'", address, owner, err)
+		return nil, appError.ErrSettingDelegate
+	}
+	return delegate, nil
+}
+
+func (app *DelegatesApp) GetDelegates(ctx context.Context, owner string) ([]*model.Delegate, error) {
+	delegates, err := app.delegatesRepository.GetDelegates(ctx, owner)
+	if err != nil {
+		app.log.Errorf("error getting delegates of owner '%s': '%s'", owner, err)
+		return nil, appError.ErrGettingDelegates
+	}
+	return delegates, nil
+}
+
+// DeleteDelegate removes a delegate of the signer, who must have signed a nonce like to set it.
+func (app *DelegatesApp) DeleteDelegate(ctx context.Context, signer *model.Principal, address string) error {
+	if signer.AuthMethod != model.AUTH_METHOD_SIGNATURE {
+		return appError.ErrDelegateNotSigned
+	}
+	owner := signer.Address
+	err := app.delegatesRepository.DeleteDelegate(ctx, owner, address)
+	if err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			return appError.ErrDelegateDoesNotExist
+		}
+		app.log.Errorf("error deleting delegate '%s' of owner '%s': '%s'", address, owner, err)
+		return appError.ErrDeletingDelegate
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package app_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	delegatesMock "github.com/AssetPortal/assets-api/pkg/adapters/delegates/mocks"
+	"github.com/AssetPortal/assets-api/pkg/app"
+	"github.com/AssetPortal/assets-api/pkg/config"
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDelegatesApp_SetDelegate_Success(t *testing.T) {
+	mockDelegatesRepository := new(delegatesMock.Repository)
+	app := app.NewDelegatesApp(&config.Configuration{}, mockDelegatesRepository, logrus.New())
+
+	mockDelegatesRepository.On("SetDelegate", mock.Anything, mock.MatchedBy(func(delegate *model.Delegate) bool {
+		return delegate.Owner == "ownerAddress" && delegate.Address == "delegateAddress" && delegate.Role == model.ROLE_PROXY
+	})).Return(func(_ context.Context, delegate *model.Delegate) (*model.Delegate, error) {
+		return delegate, nil
+	}).Once()
+
+	delegate, err := app.SetDelegate(context.Background(), signedBy("ownerAddress"), "delegateAddress", model.ROLE_PROXY)
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.ROLE_PROXY, delegate.Role)
+	mockDelegatesRepository.AssertExpectations(t)
+}
+
+func TestDelegatesApp_SetDelegate_Owner(t *testing.T) {
+	mockDelegatesRepository := new(delegatesMock.Repository)
+	app := app.NewDelegatesApp(&config.Configuration{}, mockDelegatesRepository, logrus.New())
+
+	delegate, err := app.SetDelegate(context.Background(), signedBy("ownerAddress"), "ownerAddress", model.ROLE_PROXY)
+
+	assert.Nil(t, delegate)
+	assert.Equal(t, appError.ErrDelegateIsOwner, err)
+	mockDelegatesRepository.AssertExpectations(t)
+}
+
+func TestDelegatesApp_NotSigned(t *testing.T) {
+	mockDelegatesRepository := new(delegatesMock.Repository)
+	app := app.NewDelegatesApp(&config.Configuration{}, mockDelegatesRepository, logrus.New())
+
+	for _, method := range []string{model.AUTH_METHOD_SESSION, model.AUTH_METHOD_API_KEY, model.AUTH_METHOD_DEV} {
+		signer := &model.Principal{Address: "ownerAddress", AuthMethod: method}
+
+		delegate, err := app.SetDelegate(context.Background(), signer, "delegateAddress", model.ROLE_CO_OWNER)
+		assert.Nil(t, delegate)
+		assert.Equal(t, appError.ErrDelegateNotSigned, err, method)
+
+		err = app.DeleteDelegate(context.Background(), signer, "delegateAddress")
+		assert.Equal(t, appError.ErrDelegateNotSigned, err, method)
+	}
+	mockDelegatesRepository.AssertNotCalled(t, "SetDelegate", mock.Anything, mock.Anything)
+	mockDelegatesRepository.AssertNotCalled(t, "DeleteDelegate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDelegatesApp_DeleteDelegate(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+		err     error
+	}{
+		{name: "success"},
+		{name: "does not exist", repoErr: fmt.Errorf("delegate does not exist"), err: appError.ErrDelegateDoesNotExist},
+		{name: "failure", repoErr: fmt.Errorf("db error"), err: appError.ErrDeletingDelegate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDelegatesRepository := new(delegatesMock.Repository)
+			app := app.NewDelegatesApp(&config.Configuration{}, mockDelegatesRepository, logrus.New())
+
+			mockDelegatesRepository.On("DeleteDelegate", mock.Anything, "ownerAddress", "delegateAddress").Return(tt.repoErr).Once()
+
+			err := app.DeleteDelegate(context.Background(), signedBy("ownerAddress"), "delegateAddress")
+
+			assert.Equal(t, tt.err, err)
+			mockDelegatesRepository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/adapters/assets"
+	"github.com/AssetPortal/assets-api/pkg/adapters/tokens"
+	"github.com/AssetPortal/assets-api/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+// Janitor periodically deletes expired tokens and used tokens older than the retention window,
+// and the deleted assets that cannot be restored anymore.
+type Janitor struct {
+	cfg              *config.Configuration
+	tokensRepository tokens.Repository
+	assetsRepository assets.Repository
+	log              *logrus.Logger
+}
+
+func NewJanitor(
+	cfg *config.Configuration,
+	tokensRepository tokens.Repository,
+	assetsRepository assets.Repository,
+	log *logrus.Logger,
+) *Janitor {
+	return &Janitor{
+		cfg:              cfg,
+		tokensRepository: tokensRepository,
+		assetsRepository: assetsRepository,
+		log:              log,
+	}
+}
+
+// Run purges tokens and assets on every interval until the context is cancelled.
+func (j *Janitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.cfg.JanitorConfiguration.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			j.log.Info("janitor stopped")
+			return
+		case <-ticker.C:
+			deleted, err := j.Purge(ctx)
+			if err != nil {
+				j.log.Errorf("error purging tokens: '%s'", err)
+			}
+			if deleted > 0 {
+				j.log.Infof("janitor deleted %d tokens", deleted)
+			}
+			deleted, err = j.PurgeAssets(ctx)
+			if err != nil {
+				j.log.Errorf("error purging deleted assets: '%s'", err)
+			}
+			if deleted > 0 {
+				j.log.Infof("janitor purged %d deleted assets", deleted)
+			}
+		}
+	}
+}
+
+// Purge deletes tokens in batches until a batch comes back short, and returns the number of deleted rows.
+func (j *Janitor) Purge(ctx context.Context) (int64, error) {
+	usedBefore := time.Now().Add(-j.cfg.JanitorConfiguration.Retention)
+	return j.inBatches(ctx, func(ctx context.Context, batchSize int) (int64, error) {
+		return j.tokensRepository.DeleteExpiredTokens(ctx, usedBefore, batchSize)
+	})
+}
+
+// PurgeAssets hard deletes the assets deleted before the restore window in batches, and returns the number of deleted rows.
+func (j *Janitor) PurgeAssets(ctx context.Context) (int64, error) {
+	deletedBefore := time.Now().Add(-j.cfg.AssetsConfiguration.RestoreWindow)
+	return j.inBatches(ctx, func(ctx context.Context, batchSize int) (int64, error) {
+		return j.assetsRepository.PurgeDeletedAssets(ctx, deletedBefore, batchSize)
+	})
+}
+
+// inBatches calls deleteBatch until a batch comes back short, and returns the total of deleted rows.
+func (j *Janitor) inBatches(ctx context.Context, deleteBatch func(ctx context.Context, batchSize int) (int64, error)) (int64, error) {
+	batchSize := j.cfg.JanitorConfiguration.BatchSize
+	if batchSize < 1 {
+		return 0, fmt.Errorf("the batch size must be at least 1, not %d", batchSize)
+	}
+	var total int64
+	for ctx.Err() == nil {
+		deleted, err := deleteBatch(ctx, batchSize)
+		total += deleted
+		if err != nil {
+			return total, err
+		}
+		if deleted < int64(batchSize) {
+			break
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,123 @@
+package app_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	assetsMock "github.com/AssetPortal/assets-api/pkg/adapters/assets/mocks"
+	tokensMock "github.com/AssetPortal/assets-api/pkg/adapters/tokens/mocks"
+	"github.com/AssetPortal/assets-api/pkg/app"
+	"github.com/AssetPortal/assets-api/pkg/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func janitorConfig() *config.Configuration {
+	return &config.Configuration{
+		JanitorConfiguration: config.JanitorConfiguration{
+			Enabled:   true,
+			Interval:  10 * time.Millisecond,
+			Retention: time.Hour,
+			BatchSize: 100,
+		},
+		AssetsConfiguration: config.AssetsConfiguration{
+			RestoreWindow: 24 * time.Hour,
+		},
+	}
+}
+
+func TestJanitor_Purge_Batches(t *testing.T) {
+	mockTokensRepository := new(tokensMock.Repository)
+	janitor := app.NewJanitor(janitorConfig(), mockTokensRepository, new(assetsMock.Repository), logrus.New())
+
+	retention := mock.MatchedBy(func(usedBefore time.Time) bool {
+		return time.Until(usedBefore) < -59*time.Minute
+	})
+	mockTokensRepository.On("DeleteExpiredTokens", mock.Anything, retention, 100).Return(int64(100), nil).Twice()
+	mockTokensRepository.On("DeleteExpiredTokens", mock.Anything, retention, 100).Return(int64(42), nil).Once()
+
+	deleted, err := janitor.Purge(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(242), deleted)
+	mockTokensRepository.AssertExpectations(t)
+}
+
+func TestJanitor_Purge_InvalidBatchSize(t *testing.T) {
+	mockTokensRepository := new(tokensMock.Repository)
+	cfg := janitorConfig()
+	cfg.JanitorConfiguration.BatchSize = 0
+	janitor := app.NewJanitor(cfg, mockTokensRepository, new(assetsMock.Repository), logrus.New())
+
+	deleted, err := janitor.Purge(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), deleted)
+	mockTokensRepository.AssertNotCalled(t, "DeleteExpiredTokens", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestJanitor_Purge_Error(t *testing.T) {
+	mockTokensRepository := new(tokensMock.Repository)
+	janitor := app.NewJanitor(janitorConfig(), mockTokensRepository, new(assetsMock.Repository), logrus.New())
+
+	mockTokensRepository.On("DeleteExpiredTokens", mock.Anything, mock.Anything, 100).Return(int64(100), nil).Once()
+	mockTokensRepository.On("DeleteExpiredTokens", mock.Anything, mock.Anything, 100).Return(int64(0), fmt.Errorf("db error")).Once()
+
+	deleted, err := janitor.Purge(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, int64(100), deleted)
+	mockTokensRepository.AssertExpectations(t)
+}
+
+func TestJanitor_PurgeAssets(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	janitor := app.NewJanitor(janitorConfig(), new(tokensMock.Repository), mockAssetsRepository, logrus.New())
+
+	restoreWindow := mock.MatchedBy(func(deletedBefore time.Time) bool {
+		return time.Until(deletedBefore) < -23*time.Hour
+	})
+	mockAssetsRepository.On("PurgeDeletedAssets", mock.Anything, restoreWindow, 100).Return(int64(100), nil).Once()
+	mockAssetsRepository.On("PurgeDeletedAssets", mock.Anything, restoreWindow, 100).Return(int64(7), nil).Once()
+
+	deleted, err := janitor.PurgeAssets(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(107), deleted)
+	mockAssetsRepository.AssertExpectations(t)
+}
+
+func TestJanitor_Run_StopsOnCancel(t *testing.T) {
+	mockTokensRepository := new(tokensMock.Repository)
+	mockAssetsRepository := new(assetsMock.Repository)
+	janitor := app.NewJanitor(janitorConfig(), mockTokensRepository, mockAssetsRepository, logrus.New())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	purged := make(chan struct{}, 1)
+	mockTokensRepository.On("DeleteExpiredTokens", mock.Anything, mock.Anything, 100).
+		Run(func(mock.Arguments) {
+			select {
+			case purged <- struct{}{}:
+			default:
+			}
+		}).
+		Return(int64(0), nil)
+	mockAssetsRepository.On("PurgeDeletedAssets", mock.Anything, mock.Anything, 100).Return(int64(0), nil)
+
+	done := make(chan struct{})
+	go func() {
+		janitor.Run(ctx)
+		close(done)
+	}()
+
+	<-purged
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("janitor did not stop")
+	}
+}
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/adapters/sessions"
+	"github.com/AssetPortal/assets-api/pkg/config"
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/sirupsen/logrus"
+)
+
+type SessionsApp struct {
+	cfg                *config.Configuration
+	sessionsRepository sessions.Repository
+	log                *logrus.Logger
+}
+
+func NewSessionsApp(
+	cfg *config.Configuration,
+	sessionsRepository sessions.Repository,
+	log *logrus.Logger,
+) *SessionsApp {
+	return &SessionsApp{
+		cfg:                cfg,
+		sessionsRepository: sessionsRepository,
+		log:                log,
+	}
+}
+
+// CreateSession starts a session for a principal authenticated with a signature.
+func (app *SessionsApp) CreateSession(ctx context.Context, principal *model.Principal) (*model.SessionTokens, error) {
+	if !app.cfg.SessionConfiguration.Enabled() {
+		return nil, appError.ErrSessionsDisabled
+	}
+	id, err := randomHex(16)
+	if err != nil {
+		app.log.Errorf("error generating session id: '%s'", err)
+		return nil, appError.ErrCreatingSession
+	}
+	refreshToken, err := randomHex(32)
+	if err != nil {
+		app.log.Errorf("error generating refresh token: '%s'", err)
+		return nil, appError.ErrCreatingSession
+	}
+	now := time.Now()
+	session := &model.Session{
+		ID:               id,
+		Address:          principal.Address,
+		Network:          principal.Network,
+		KeyType:          principal.KeyType,
+		RefreshTokenHash: model.HashSecret(refreshToken),
+		CreatedAt:        now,
+		ExpiresAt:        now.Add(app.cfg.SessionConfiguration.RefreshTokenTTL),
+	}
+	session, err = app.sessionsRepository.CreateSession(ctx, session)
+	if err != nil {
+		app.log.Errorf("error creating session: '%s'", err)
+		return nil, appError.ErrCreatingSession
+	}
+	tokens, err := app.issueTokens(session, refreshToken, now)
+	if err != nil {
+		app.log.Errorf("error signing access token: '%s'", err)
+		return nil, appError.ErrCreatingSession
+	}
+	return tokens, nil
+}
+
+// RefreshSession exchanges a refresh token for a new access token and refresh token.
+func (app *SessionsApp) RefreshSession(ctx context.Context, refreshToken string) (*model.SessionTokens, error) {
+	if !app.cfg.SessionConfiguration.Enabled() {
+		return nil, appError.ErrSessionsDisabled
+	}
+	oldHash := model.HashSecret(refreshToken)
+	session, err := app.sessionsRepository.GetSessionByRefreshToken(ctx, oldHash)
+	if err != nil {
+		app.log.Errorf("error getting session by refresh token: '%s'", err)
+		return nil, appError.ErrRefreshingSession
+	}
+	if session == nil || !session.IsValid() {
+		return nil, appError.ErrInvalidRefreshToken
+	}
+	newRefreshToken, err := randomHex(32)
+	if err != nil {
+		app.log.Errorf("error generating refresh token: '%s'", err)
+		return nil, appError.ErrRefreshingSession
+	}
+	now := time.Now()
+	session.RefreshTokenHash = model.HashSecret(newRefreshToken)
+	session.ExpiresAt = now.Add(app.cfg.SessionConfiguration.RefreshTokenTTL)
+	err = app.sessionsRepository.RotateRefreshToken(ctx, session.ID, oldHash, session.RefreshTokenHash, session.ExpiresAt)
+	if err != nil {
+		app.log.Errorf("error rotating refresh token of session '%s': '%s'", session.ID, err)
+		return nil, appError.ErrInvalidRefreshToken
+	}
+	tokens, err := app.issueTokens(session, newRefreshToken, now)
+	if err != nil {
+		app.log.Errorf("error signing access token: '%s'", err)
+		return nil, appError.ErrRefreshingSession
+	}
+	return tokens, nil
+}
+
+func (app *SessionsApp) RevokeSession(ctx context.Context, id string) error {
+	if err := app.sessionsRepository.RevokeSession(ctx, id); err != nil {
+		app.log.Errorf("error revoking session '%s': '%s'", id, err)
+		return appError.ErrRevokingSession
+	}
+	return nil
+}
+
+func (app *SessionsApp) issueTokens(session *model.Session, refreshToken string, now time.Time) (*model.SessionTokens, error) {
+	expiresAt := now.Add(app.cfg.SessionConfiguration.AccessTokenTTL)
+	if expiresAt.After(session.ExpiresAt) {
+		expiresAt = session.ExpiresAt
+	}
+	accessToken, err := model.NewSessionClaims(session, now, expiresAt).Sign([]byte(app.cfg.SessionConfiguration.Secret))
+	if err != nil {
+		return nil, err
+	}
+	return &model.SessionTokens{
+		TokenType:        "Bearer",
+		AccessToken:      accessToken,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+func randomHex(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,164 @@
+package app_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	sessionsMock "github.com/AssetPortal/assets-api/pkg/adapters/sessions/mocks"
+	"github.com/AssetPortal/assets-api/pkg/app"
+	"github.com/AssetPortal/assets-api/pkg/config"
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func sessionsConfig() *config.Configuration {
+	return &config.Configuration{
+		SessionConfiguration: config.SessionConfiguration{
+			Secret:          "secret",
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 24 * time.Hour,
+		},
+	}
+}
+
+func TestSessionsApp_CreateSession_Success(t *testing.T) {
+	mockSessionsRepository := new(sessionsMock.Repository)
+	app := app.NewSessionsApp(sessionsConfig(), mockSessionsRepository, logrus.New())
+
+	var stored *model.Session
+	mockSessionsRepository.On("CreateSession", mock.Anything, mock.AnythingOfType("*model.Session")).
+		Return(func(_ context.Context, session *model.Session) (*model.Session, error) {
+			stored = session
+			return session, nil
+		}).Once()
+
+	tokens, err := app.CreateSession(context.Background(), &model.Principal{
+		Address:    "userAddress",
+		KeyType:    model.KEY_TYPE_SR25519,
+		AuthMethod: model.AUTH_METHOD_SIGNATURE,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer", tokens.TokenType)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.NotEqual(t, tokens.RefreshToken, stored.RefreshTokenHash)
+	assert.Equal(t, "userAddress", stored.Address)
+
+	claims, err := model.ParseSessionToken(tokens.AccessToken, []byte("secret"))
+	assert.NoError(t, err)
+	assert.Equal(t, stored.ID, claims.ID)
+	assert.Equal(t, "userAddress", claims.Subject)
+
+	mockSessionsRepository.AssertExpectations(t)
+}
+
+func TestSessionsApp_CreateSession_Disabled(t *testing.T) {
+	mockSessionsRepository := new(sessionsMock.Repository)
+	app := app.NewSessionsApp(&config.Configuration{}, mockSessionsRepository, logrus.New())
+
+	tokens, err := app.CreateSession(context.Background(), &model.Principal{Address: "userAddress"})
+
+	assert.Nil(t, tokens)
+	assert.Equal(t, appError.ErrSessionsDisabled, err)
+	mockSessionsRepository.AssertExpectations(t)
+}
+
+func TestSessionsApp_CreateSession_Failure(t *testing.T) {
+	mockSessionsRepository := new(sessionsMock.Repository)
+	app := app.NewSessionsApp(sessionsConfig(), mockSessionsRepository, logrus.New())
+
+	mockSessionsRepository.On("CreateSession", mock.Anything, mock.AnythingOfType("*model.Session")).
+		Return(nil, fmt.Errorf("database error")).Once()
+
+	tokens, err := app.CreateSession(context.Background(), &model.Principal{Address: "userAddress"})
+
+	assert.Nil(t, tokens)
+	assert.Equal(t, appError.ErrCreatingSession, err)
+	mockSessionsRepository.AssertExpectations(t)
+}
+
+func TestSessionsApp_RefreshSession_Success(t *testing.T) {
+	mockSessionsRepository := new(sessionsMock.Repository)
+	app := app.NewSessionsApp(sessionsConfig(), mockSessionsRepository, logrus.New())
+
+	session := &model.Session{
+		ID:        "session123",
+		Address:   "userAddress",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	mockSessionsRepository.On("GetSessionByRefreshToken", mock.Anything, mock.AnythingOfType("string")).
+		Return(session, nil).Once()
+	mockSessionsRepository.On("RotateRefreshToken", mock.Anything, "session123", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Return(nil).Once()
+
+	tokens, err := app.RefreshSession(context.Background(), "refreshToken")
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, "refreshToken", tokens.RefreshToken)
+	claims, err := model.ParseSessionToken(tokens.AccessToken, []byte("secret"))
+	assert.NoError(t, err)
+	assert.Equal(t, "session123", claims.ID)
+
+	mockSessionsRepository.AssertExpectations(t)
+}
+
+func TestSessionsApp_RefreshSession_Invalid(t *testing.T) {
+	revokedAt := time.Now()
+	tests := []struct {
+		name    string
+		session *model.Session
+	}{
+		{name: "unknown refresh token", session: nil},
+		{name: "revoked session", session: &model.Session{ID: "session123", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}},
+		{name: "expired session", session: &model.Session{ID: "session123", ExpiresAt: time.Now().Add(-time.Hour)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSessionsRepository := new(sessionsMock.Repository)
+			app := app.NewSessionsApp(sessionsConfig(), mockSessionsRepository, logrus.New())
+
+			mockSessionsRepository.On("GetSessionByRefreshToken", mock.Anything, mock.AnythingOfType("string")).
+				Return(tt.session, nil).Once()
+
+			tokens, err := app.RefreshSession(context.Background(), "refreshToken")
+
+			assert.Nil(t, tokens)
+			assert.Equal(t, appError.ErrInvalidRefreshToken, err)
+			mockSessionsRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionsApp_RefreshSession_AlreadyRotated(t *testing.T) {
+	mockSessionsRepository := new(sessionsMock.Repository)
+	app := app.NewSessionsApp(sessionsConfig(), mockSessionsRepository, logrus.New())
+
+	session := &model.Session{ID: "session123", ExpiresAt: time.Now().Add(time.Hour)}
+	mockSessionsRepository.On("GetSessionByRefreshToken", mock.Anything, mock.AnythingOfType("string")).
+		Return(session, nil).Once()
+	mockSessionsRepository.On("RotateRefreshToken", mock.Anything, "session123", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Return(fmt.Errorf("does not exist")).Once()
+
+	tokens, err := app.RefreshSession(context.Background(), "refreshToken")
+
+	assert.Nil(t, tokens)
+	assert.Equal(t, appError.ErrInvalidRefreshToken, err)
+	mockSessionsRepository.AssertExpectations(t)
+}
+
+func TestSessionsApp_RevokeSession(t *testing.T) {
+	mockSessionsRepository := new(sessionsMock.Repository)
+	app := app.NewSessionsApp(sessionsConfig(), mockSessionsRepository, logrus.New())
+
+	mockSessionsRepository.On("RevokeSession", mock.Anything, "session123").Return(nil).Once()
+	mockSessionsRepository.On("RevokeSession", mock.Anything, "session456").Return(fmt.Errorf("database error")).Once()
+
+	assert.NoError(t, app.RevokeSession(context.Background(), "session123"))
+	assert.Equal(t, appError.ErrRevokingSession, app.RevokeSession(context.Background(), "session456"))
+	mockSessionsRepository.AssertExpectations(t)
+}
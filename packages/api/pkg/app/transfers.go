@@ -0,0 +1,149 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/adapters/assets"
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+)
+
+// OfferTransfer offers the asset of the signer, who must be its owner, to the recipient.
+// The offer replaces the pending one of the asset, if any, and expires after the transfer TTL.
+// The signer must have signed a nonce, so that the offer records it.
+func (app *AssetsApp) OfferTransfer(ctx context.Context, id, recipient string, signer *model.Principal) (*model.AssetTransfer, error) {
+	if signer.AuthMethod != model.AUTH_METHOD_SIGNATURE {
+		return nil, appError.ErrTransferNotSigned
+	}
+	if recipient == signer.Address {
+		return nil, appError.ErrTransferToOwner
+	}
+	now := time.Now()
+	transfer := model.NewAssetTransfer(id, recipient, signer, now, app.cfg.AssetsConfiguration.TransferTTL)
+	err := app.inTx(ctx, app.assetsRepository, appError.ErrOfferingTransfer, func(ctx context.Context, repo assets.Repository) error {
+		asset, err := repo.GetAssetByID(ctx, id)
+		if err != nil {
+			app.log.Errorf("error getting asset by id '%s': '%s'", id, err)
+			return appError.ErrGettingAsset
+		}
+		if asset == nil || asset.Address != signer.Address {
+			return appError.ErrAssetDoesNotBelongToTheUser
+		}
+		if err := repo.CancelPendingTransfers(ctx, id, signer.Address, now); err != nil {
+			app.log.Errorf("error cancelling the pending transfers of asset '%s': '%s'", id, err)
+			return appError.ErrOfferingTransfer
+		}
+		if err := repo.CreateTransfer(ctx, transfer); err != nil {
+			app.log.Errorf("error offering asset '%s' to '%s': '%s'", id, recipient, err)
+			return appError.ErrOfferingTransfer
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// AcceptTransfer makes the signer, who must be the recipient of the pending offer, the owner of the asset.
+// Like the offer, the acceptance must be signed.
+func (app *AssetsApp) AcceptTransfer(ctx context.Context, id string, signer *model.Principal) (*model.Asset, error) {
+	if signer.AuthMethod != model.AUTH_METHOD_SIGNATURE {
+		return nil, appError.ErrTransferNotSigned
+	}
+	var asset *model.Asset
+	err := app.inTx(ctx, app.assetsRepository, appError.ErrAcceptingTransfer, func(ctx context.Context, repo assets.Repository) error {
+		transfer, err := app.getPendingTransfer(ctx, repo, id)
+		if err != nil {
+			return err
+		}
+		if transfer == nil || transfer.To != signer.Address {
+			return appError.ErrTransferDoesNotExist
+		}
+		err = repo.TransferAsset(ctx, id, transfer.From, transfer.To)
+		if err != nil {
+			// The asset was deleted since the offer.
+			if strings.Contains(err.Error(), "does not exist") {
+				return appError.ErrTransferDoesNotExist
+			}
+			app.log.Errorf("error transferring asset '%s' to '%s': '%s'", id, transfer.To, err)
+			return appError.ErrAcceptingTransfer
+		}
+		transfer.Accept(signer, time.Now())
+		if err := repo.UpdateTransfer(ctx, transfer); err != nil {
+			app.log.Errorf("error accepting transfer of asset '%s': '%s'", id, err)
+			return appError.ErrAcceptingTransfer
+		}
+		if err := app.recordRevision(ctx, repo, id, model.REVISION_TRANSFER, signer); err != nil {
+			return err
+		}
+		asset, err = repo.GetAssetByID(ctx, id)
+		if err != nil {
+			app.log.Errorf("error getting asset by id '%s': '%s'", id, err)
+			return appError.ErrGettingAsset
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// CancelTransfer withdraws the pending offer of the asset on behalf of the signer, who must be
+// its owner or recipient.
+func (app *AssetsApp) CancelTransfer(ctx context.Context, id string, signer *model.Principal) (*model.AssetTransfer, error) {
+	var transfer *model.AssetTransfer
+	err := app.inTx(ctx, app.assetsRepository, appError.ErrCancellingTransfer, func(ctx context.Context, repo assets.Repository) error {
+		var err error
+		transfer, err = app.getPendingTransfer(ctx, repo, id)
+		if err != nil {
+			return err
+		}
+		if transfer == nil || (transfer.From != signer.Address && transfer.To != signer.Address) {
+			return appError.ErrTransferDoesNotExist
+		}
+		transfer.Cancel(signer.Address, time.Now())
+		if err := repo.UpdateTransfer(ctx, transfer); err != nil {
+			app.log.Errorf("error cancelling transfer of asset '%s': '%s'", id, err)
+			return appError.ErrCancellingTransfer
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (app *AssetsApp) getPendingTransfer(ctx context.Context, repo assets.Repository, id string) (*model.AssetTransfer, error) {
+	transfer, err := repo.GetPendingTransfer(ctx, id)
+	if err != nil {
+		app.log.Errorf("error getting pending transfer of asset '%s': '%s'", id, err)
+		return nil, appError.ErrGettingTransfers
+	}
+	return transfer, nil
+}
+
+// GetTransfers returns a page of the transfers of the asset, the latest first. Pending offers
+// past their expiration are reported as expired. Like its revisions, the transfers of a deleted
+// asset are hidden.
+func (app *AssetsApp) GetTransfers(ctx context.Context, id string, pagination *model.Pagination) ([]*model.AssetTransfer, error) {
+	if err := app.checkHistory(ctx, id); err != nil {
+		return nil, err
+	}
+	transfers, err := app.assetsRepository.GetTransfers(ctx, id, *pagination.Limit, *pagination.Offset)
+	if err != nil {
+		app.log.Errorf("error getting transfers of asset '%s': '%s'", id, err)
+		return nil, appError.ErrGettingTransfers
+	}
+	now := time.Now()
+	for _, transfer := range transfers {
+		if transfer.IsExpired(now) {
+			transfer.Status = model.TRANSFER_EXPIRED
+		}
+	}
+	return transfers, nil
+}
@@ -0,0 +1,189 @@
+package app_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	assetsMock "github.com/AssetPortal/assets-api/pkg/adapters/assets/mocks"
+	"github.com/AssetPortal/assets-api/pkg/app"
+	"github.com/AssetPortal/assets-api/pkg/config"
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func pendingTransfer() *model.AssetTransfer {
+	return model.NewAssetTransfer("asset123", "recipientAddress", signedBy("ownerAddress"), time.Now(), time.Hour)
+}
+
+func TestAssetsApp_OfferTransfer_Success(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	cfg := &config.Configuration{AssetsConfiguration: config.AssetsConfiguration{TransferTTL: time.Hour}}
+	app := app.NewAssetsApp(cfg, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+		Return(&model.Asset{ID: "asset123", Address: "ownerAddress"}, nil).Once()
+	mockAssetsRepository.On("CancelPendingTransfers", mock.Anything, "asset123", "ownerAddress", mock.Anything).Return(nil).Once()
+	mockAssetsRepository.On("CreateTransfer", mock.Anything, mock.MatchedBy(func(transfer *model.AssetTransfer) bool {
+		return transfer.From == "ownerAddress" && transfer.To == "recipientAddress" && transfer.Status == model.TRANSFER_PENDING
+	})).Return(nil).Once()
+
+	transfer, err := app.OfferTransfer(context.Background(), "asset123", "recipientAddress", signedBy("ownerAddress"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "nonce", *transfer.OfferNonce)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), transfer.ExpiresAt, time.Minute)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_Transfer_NotSigned(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	for _, method := range []string{model.AUTH_METHOD_SESSION, model.AUTH_METHOD_DEV} {
+		signer := &model.Principal{Address: "ownerAddress", AuthMethod: method}
+
+		transfer, err := app.OfferTransfer(context.Background(), "asset123", "recipientAddress", signer)
+		assert.Nil(t, transfer)
+		assert.Equal(t, appError.ErrTransferNotSigned, err, method)
+
+		signer.Address = "recipientAddress"
+		asset, err := app.AcceptTransfer(context.Background(), "asset123", signer)
+		assert.Nil(t, asset)
+		assert.Equal(t, appError.ErrTransferNotSigned, err, method)
+	}
+	mockAssetsRepository.AssertNotCalled(t, "RunInTx", mock.Anything, mock.Anything)
+}
+func TestAssetsApp_OfferTransfer_NotOwner(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").
+		Return(&model.Asset{ID: "asset123", Address: "ownerAddress"}, nil).Once()
+
+	transfer, err := app.OfferTransfer(context.Background(), "asset123", "recipientAddress", signedBy("delegateAddress"))
+
+	assert.Nil(t, transfer)
+	assert.Equal(t, appError.ErrAssetDoesNotBelongToTheUser, err)
+	mockAssetsRepository.AssertNotCalled(t, "CreateTransfer", mock.Anything, mock.Anything)
+}
+func TestAssetsApp_OfferTransfer_ToOwner(t *testing.T) {
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, new(assetsMock.Repository), nil, nil, logrus.New())
+
+	_, err := app.OfferTransfer(context.Background(), "asset123", "ownerAddress", signedBy("ownerAddress"))
+
+	assert.Equal(t, appError.ErrTransferToOwner, err)
+}
+func TestAssetsApp_AcceptTransfer_Success(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	asset := &model.Asset{ID: "asset123", Address: "recipientAddress"}
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetPendingTransfer", mock.Anything, "asset123").Return(pendingTransfer(), nil).Once()
+	mockAssetsRepository.On("TransferAsset", mock.Anything, "asset123", "ownerAddress", "recipientAddress").Return(nil).Once()
+	mockAssetsRepository.On("UpdateTransfer", mock.Anything, mock.MatchedBy(func(transfer *model.AssetTransfer) bool {
+		return transfer.Status == model.TRANSFER_ACCEPTED && *transfer.AcceptNonce == "nonce"
+	})).Return(nil).Once()
+	mockAssetsRepository.On("CreateRevision", mock.Anything, revisionOf("asset123", model.REVISION_TRANSFER, "recipientAddress")).
+		Return(nil).Once()
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").Return(asset, nil).Once()
+
+	accepted, err := app.AcceptTransfer(context.Background(), "asset123", signedBy("recipientAddress"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, asset, accepted)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_AcceptTransfer_NotRecipient(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetPendingTransfer", mock.Anything, "asset123").Return(pendingTransfer(), nil).Once()
+
+	accepted, err := app.AcceptTransfer(context.Background(), "asset123", signedBy("ownerAddress"))
+
+	assert.Nil(t, accepted)
+	assert.Equal(t, appError.ErrTransferDoesNotExist, err)
+	mockAssetsRepository.AssertNotCalled(t, "TransferAsset", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+func TestAssetsApp_AcceptTransfer_AssetDeleted(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetPendingTransfer", mock.Anything, "asset123").Return(pendingTransfer(), nil).Once()
+	mockAssetsRepository.On("TransferAsset", mock.Anything, "asset123", "ownerAddress", "recipientAddress").
+		Return(fmt.Errorf("asset with id 'asset123' and address 'ownerAddress' does not exist")).Once()
+
+	_, err := app.AcceptTransfer(context.Background(), "asset123", signedBy("recipientAddress"))
+
+	assert.Equal(t, appError.ErrTransferDoesNotExist, err)
+	mockAssetsRepository.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything)
+}
+func TestAssetsApp_CancelTransfer_ByRecipient(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetPendingTransfer", mock.Anything, "asset123").Return(pendingTransfer(), nil).Once()
+	mockAssetsRepository.On("UpdateTransfer", mock.Anything, mock.AnythingOfType("*model.AssetTransfer")).Return(nil).Once()
+
+	transfer, err := app.CancelTransfer(context.Background(), "asset123", signedBy("recipientAddress"))
+
+	require.NoError(t, err)
+	assert.Equal(t, model.TRANSFER_CANCELLED, transfer.Status)
+	assert.Equal(t, "recipientAddress", *transfer.CancelledBy)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_CancelTransfer_NoPendingTransfer(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	runInTx(mockAssetsRepository)
+	mockAssetsRepository.On("GetPendingTransfer", mock.Anything, "asset123").Return(nil, nil).Once()
+
+	_, err := app.CancelTransfer(context.Background(), "asset123", signedBy("ownerAddress"))
+
+	assert.Equal(t, appError.ErrTransferDoesNotExist, err)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_GetTransfers_Expired(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	expired := model.NewAssetTransfer("asset123", "recipientAddress", signedBy("ownerAddress"), time.Now().Add(-2*time.Hour), time.Hour)
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").Return(&model.Asset{ID: "asset123"}, nil).Once()
+	mockAssetsRepository.On("GetTransfers", mock.Anything, "asset123", 10, 0).
+		Return([]*model.AssetTransfer{pendingTransfer(), expired}, nil).Once()
+
+	limit, offset := 10, 0
+	transfers, err := app.GetTransfers(context.Background(), "asset123", &model.Pagination{Limit: &limit, Offset: &offset})
+
+	require.NoError(t, err)
+	assert.Equal(t, model.TRANSFER_PENDING, transfers[0].Status)
+	assert.Equal(t, model.TRANSFER_EXPIRED, transfers[1].Status)
+	mockAssetsRepository.AssertExpectations(t)
+}
+func TestAssetsApp_GetTransfers_DeletedAsset(t *testing.T) {
+	mockAssetsRepository := new(assetsMock.Repository)
+	app := app.NewAssetsApp(&config.Configuration{}, nil, nil, mockAssetsRepository, nil, nil, logrus.New())
+
+	// Deleted assets are not found, like purged ones.
+	mockAssetsRepository.On("GetAssetByID", mock.Anything, "asset123").Return(nil, nil).Once()
+
+	limit, offset := 10, 0
+	transfers, err := app.GetTransfers(context.Background(), "asset123", &model.Pagination{Limit: &limit, Offset: &offset})
+
+	assert.Nil(t, transfers)
+	assert.Equal(t, appError.ErrHistoryNotFound, err)
+	mockAssetsRepository.AssertNotCalled(t, "GetTransfers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockAssetsRepository.AssertExpectations(t)
+}
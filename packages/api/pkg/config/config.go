@@ -1,6 +1,8 @@
 package config
 
 import (
+	"errors"
+	"slices"
 	"time"
 
 	"github.com/caarlos0/env/v11"
@@ -13,7 +15,14 @@ type Configuration struct {
 	MaxRequestsPerSecond  int                   `env:"MAX_REQUESTS_PER_SECOND" envDefault:"3"`
 	LogLevel              string                `env:"LOG_LEVEL" envDefault:"warn"`
 	TokenExpiration       time.Duration         `env:"TOKEN_EXPIRATION" envDefault:"5m"`
+	AdminAddresses        []string              `env:"ADMIN_ADDRESSES"`
+	AssetsConfiguration   AssetsConfiguration   `envPrefix:"ASSETS_"`
+	NetworksConfiguration NetworksConfiguration `envPrefix:"NETWORKS_"`
 	AuthConfiguration     AuthConfiguration     `envPrefix:"AUTH_"`
+	SessionConfiguration  SessionConfiguration  `envPrefix:"SESSION_"`
+	JanitorConfiguration  JanitorConfiguration  `envPrefix:"JANITOR_"`
+	NonceConfiguration    NonceConfiguration    `envPrefix:"NONCE_"`
+	APIKeyConfiguration   APIKeyConfiguration   `envPrefix:"API_KEY_"`
 	BucketConfiguration   BucketConfiguration   `envPrefix:"BUCKET_"`
 	DatabaseConfiguration DatabaseConfiguration `envPrefix:"DATABASE_"`
 }
@@ -25,9 +34,80 @@ type DatabaseConfiguration struct {
 	URL             string        `env:"URL" envDefault:"localhost:5432"`
 }
 type AuthConfiguration struct {
+	Provider    string        `env:"PROVIDER" envDefault:"native"`
 	APIURL      string        `env:"API_URL"`
 	HTTPTimeout time.Duration `env:"HTTP_TIMEOUT" envDefault:"20s"`
 	Enabled     bool          `env:"ENABLED" envDefault:"true"`
+	DevAddress  string        `env:"DEV_ADDRESS"`
+	Domain      string        `env:"DOMAIN"`
+	URI         string        `env:"URI"`
+}
+
+// SessionConfiguration configures the bearer tokens issued by /auth/session.
+// Sessions are disabled when no secret is set.
+type SessionConfiguration struct {
+	Secret          string        `env:"SECRET"`
+	AccessTokenTTL  time.Duration `env:"ACCESS_TOKEN_TTL" envDefault:"15m"`
+	RefreshTokenTTL time.Duration `env:"REFRESH_TOKEN_TTL" envDefault:"24h"`
+}
+
+func (c SessionConfiguration) Enabled() bool {
+	return c.Secret != ""
+}
+
+// IsAdmin returns true if the canonical address is one of the admins, who can list the deleted assets.
+// The admins can be configured with their addresses for any network, cmd/main.go canonicalizes them
+// at startup.
+func (c *Configuration) IsAdmin(address string) bool {
+	return slices.Contains(c.AdminAddresses, address)
+}
+
+// AssetsConfiguration configures the deleted assets, which their owners can restore
+// until the janitor purges them after the restore window, and how long transfer offers last.
+type AssetsConfiguration struct {
+	RestoreWindow time.Duration `env:"RESTORE_WINDOW" envDefault:"720h"`
+	TransferTTL   time.Duration `env:"TRANSFER_TTL" envDefault:"168h"`
+}
+
+// NetworksConfiguration configures the registry of the networks that assets can be registered on.
+// Without a file, they are Polkadot and Kusama.
+type NetworksConfiguration struct {
+	File string `env:"FILE"`
+}
+
+// JanitorConfiguration configures the background purge of expired and used tokens, and of deleted assets.
+type JanitorConfiguration struct {
+	Enabled   bool          `env:"ENABLED" envDefault:"true"`
+	Interval  time.Duration `env:"INTERVAL" envDefault:"10m"`
+	Retention time.Duration `env:"RETENTION" envDefault:"24h"`
+	BatchSize int           `env:"BATCH_SIZE" envDefault:"1000"`
+}
+
+func (c JanitorConfiguration) Validate() error {
+	if c.Interval <= 0 {
+		return errors.New("JANITOR_INTERVAL must be positive")
+	}
+	if c.BatchSize < 1 {
+		return errors.New("JANITOR_BATCH_SIZE must be at least 1")
+	}
+	return nil
+}
+
+// NonceConfiguration throttles GET /nonce per client IP and per address, and caps the unused
+// nonces of each client IP.
+// A zero limit disables the corresponding check.
+type NonceConfiguration struct {
+	RateLimit      int           `env:"RATE_LIMIT" envDefault:"30"`
+	RateWindow     time.Duration `env:"RATE_WINDOW" envDefault:"1m"`
+	Burst          int           `env:"BURST" envDefault:"5"`
+	MaxOutstanding int           `env:"MAX_OUTSTANDING" envDefault:"10"`
+	TrustProxy     bool          `env:"TRUST_PROXY" envDefault:"false"`
+}
+
+// APIKeyConfiguration limits the API keys minted by owners.
+type APIKeyConfiguration struct {
+	MaxTTL      time.Duration `env:"MAX_TTL" envDefault:"8760h"`
+	MaxPerOwner int           `env:"MAX_PER_OWNER" envDefault:"20"`
 }
 
 type BucketConfiguration struct {
@@ -41,6 +121,11 @@ type BucketConfiguration struct {
 func MustGetConfig() (*Configuration, error) {
 	_ = gotenv.Load()
 	cfg := &Configuration{}
-	err := env.Parse(cfg)
-	return cfg, err
+	if err := env.Parse(cfg); err != nil {
+		return cfg, err
+	}
+	if cfg.JanitorConfiguration.Enabled {
+		return cfg, cfg.JanitorConfiguration.Validate()
+	}
+	return cfg, nil
 }
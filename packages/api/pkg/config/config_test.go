@@ -17,6 +17,7 @@ func TestMustGetConfig_Success(t *testing.T) {
 	os.Setenv("MAX_REQUESTS_PER_SECOND", "20")
 	os.Setenv("LOG_LEVEL", "info")
 	os.Setenv("TOKEN_EXPIRATION", "10m")
+	os.Setenv("AUTH_PROVIDER", "http")
 	os.Setenv("AUTH_API_URL", "http://auth.example.com")
 	os.Setenv("AUTH_HTTP_TIMEOUT", "15s")
 	os.Setenv("BUCKET_ACCESS_KEY", "my-access-key")
@@ -38,6 +39,7 @@ func TestMustGetConfig_Success(t *testing.T) {
 	assert.Equal(t, 20, cfg.MaxRequestsPerSecond)
 	assert.Equal(t, "info", cfg.LogLevel)
 	assert.Equal(t, 10*time.Minute, cfg.TokenExpiration)
+	assert.Equal(t, "http", cfg.AuthConfiguration.Provider)
 	assert.Equal(t, "http://auth.example.com", cfg.AuthConfiguration.APIURL)
 	assert.Equal(t, 15*time.Second, cfg.AuthConfiguration.HTTPTimeout)
 	assert.Equal(t, "my-access-key", cfg.BucketConfiguration.AccessKey)
@@ -55,6 +57,7 @@ func TestMustGetConfig_DefaultValues(t *testing.T) {
 	os.Unsetenv("MAX_REQUESTS_PER_SECOND")
 	os.Unsetenv("LOG_LEVEL")
 	os.Unsetenv("TOKEN_EXPIRATION")
+	os.Unsetenv("AUTH_PROVIDER")
 	os.Unsetenv("AUTH_API_URL")
 	os.Unsetenv("AUTH_HTTP_TIMEOUT")
 	os.Unsetenv("BUCKET_ACCESS_KEY")
@@ -76,6 +79,21 @@ func TestMustGetConfig_DefaultValues(t *testing.T) {
 	assert.Equal(t, 3, cfg.MaxRequestsPerSecond)
 	assert.Equal(t, "warn", cfg.LogLevel)
 	assert.Equal(t, 5*time.Minute, cfg.TokenExpiration)
+	assert.Equal(t, "native", cfg.AuthConfiguration.Provider)
+	assert.False(t, cfg.SessionConfiguration.Enabled())
+	assert.Equal(t, 15*time.Minute, cfg.SessionConfiguration.AccessTokenTTL)
+	assert.Equal(t, 24*time.Hour, cfg.SessionConfiguration.RefreshTokenTTL)
+	assert.True(t, cfg.JanitorConfiguration.Enabled)
+	assert.Equal(t, 10*time.Minute, cfg.JanitorConfiguration.Interval)
+	assert.Equal(t, 24*time.Hour, cfg.JanitorConfiguration.Retention)
+	assert.Equal(t, 1000, cfg.JanitorConfiguration.BatchSize)
+	assert.Equal(t, 30, cfg.NonceConfiguration.RateLimit)
+	assert.Equal(t, time.Minute, cfg.NonceConfiguration.RateWindow)
+	assert.Equal(t, 5, cfg.NonceConfiguration.Burst)
+	assert.Equal(t, 10, cfg.NonceConfiguration.MaxOutstanding)
+	assert.False(t, cfg.NonceConfiguration.TrustProxy)
+	assert.Equal(t, 365*24*time.Hour, cfg.APIKeyConfiguration.MaxTTL)
+	assert.Equal(t, 20, cfg.APIKeyConfiguration.MaxPerOwner)
 	assert.Equal(t, "", cfg.AuthConfiguration.APIURL)
 	assert.Equal(t, 20*time.Second, cfg.AuthConfiguration.HTTPTimeout)
 	assert.Equal(t, "test", cfg.BucketConfiguration.AccessKey)
@@ -84,3 +102,19 @@ func TestMustGetConfig_DefaultValues(t *testing.T) {
 	assert.Equal(t, "", cfg.BucketConfiguration.Region)
 	assert.Equal(t, "", cfg.BucketConfiguration.Name)
 }
+
+func TestMustGetConfig_InvalidJanitor(t *testing.T) {
+	t.Setenv("JANITOR_BATCH_SIZE", "0")
+	_, err := config.MustGetConfig()
+	assert.ErrorContains(t, err, "JANITOR_BATCH_SIZE")
+
+	t.Setenv("JANITOR_BATCH_SIZE", "1000")
+	t.Setenv("JANITOR_INTERVAL", "0s")
+	_, err = config.MustGetConfig()
+	assert.ErrorContains(t, err, "JANITOR_INTERVAL")
+
+	// A disabled janitor is not validated.
+	t.Setenv("JANITOR_ENABLED", "false")
+	_, err = config.MustGetConfig()
+	assert.NoError(t, err)
+}
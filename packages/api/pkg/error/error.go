@@ -4,6 +4,9 @@ import "errors"
 
 var ErrGeneratingToken = errors.New("error generating token")
 var ErrCreatingToken = errors.New("error creating token in database")
+var ErrSIWSDisabled = errors.New("sign in messages are not enabled")
+var ErrCountingTokens = errors.New("error counting tokens in database")
+var ErrTooManyOutstandingTokens = errors.New("too many unused nonces, use or let them expire before requesting more")
 
 // assets
 var ErrCreatingAsset = errors.New("error creating asset in database")
@@ -11,7 +14,53 @@ var ErrGettingAsset = errors.New("error getting asset in database")
 var ErrGettingAssets = errors.New("error getting assets in database")
 var ErrUpdatingAsset = errors.New("error updating asset in database")
 var ErrDeletingAsset = errors.New("error deleting asset in database")
+var ErrRestoringAsset = errors.New("error restoring asset in database")
 
 var ErrCreatingAssetIDExists = errors.New("id exists")
+var ErrAddressNotOnBlockchain = errors.New("the address of the signer is not encoded for the blockchain of the asset")
 var ErrUploadingFile = errors.New("error uploading file")
 var ErrAssetDoesNotBelongToTheUser = errors.New("asset does not exist or do not belong to the user")
+var ErrInvalidPatch = errors.New("the patch cannot be applied to the asset")
+var ErrInvalidBlockchain = errors.New("invalid blockchain")
+var ErrAssetVersionMismatch = errors.New("asset was modified, its version does not match If-Match")
+var ErrAssetNotRestorable = errors.New("asset is not deleted, does not belong to the user or its restore window expired")
+var ErrRecordingRevision = errors.New("error recording asset revision in database")
+var ErrGettingRevisions = errors.New("error getting asset revisions in database")
+var ErrRevisionDoesNotExist = errors.New("revision does not exist")
+var ErrHistoryNotFound = errors.New("asset not found, the history of deleted assets is hidden")
+var ErrBulkRolledBack = errors.New("not applied, the batch was rolled back")
+var ErrApplyingBulk = errors.New("error applying the batch of assets in database")
+
+// transfers
+var ErrOfferingTransfer = errors.New("error storing transfer offer in database")
+var ErrAcceptingTransfer = errors.New("error accepting transfer in database")
+var ErrCancellingTransfer = errors.New("error cancelling transfer in database")
+var ErrGettingTransfers = errors.New("error getting asset transfers in database")
+var ErrTransferDoesNotExist = errors.New("there is no pending transfer of the asset for the user")
+var ErrTransferToOwner = errors.New("an asset cannot be transferred to its owner")
+var ErrTransferNotSigned = errors.New("a transfer can only be offered or accepted with a signed nonce")
+
+// delegates
+var ErrSettingDelegate = errors.New("error storing delegate in database")
+var ErrGettingDelegate = errors.New("error getting delegate in database")
+var ErrGettingDelegates = errors.New("error getting delegates in database")
+var ErrDeletingDelegate = errors.New("error deleting delegate in database")
+var ErrDelegateDoesNotExist = errors.New("delegate does not exist")
+var ErrDelegateIsOwner = errors.New("an address cannot be its own delegate")
+var ErrDelegateNotSigned = errors.New("delegates can only be managed with a signed nonce")
+
+// api keys
+var ErrCreatingAPIKey = errors.New("error creating API key in database")
+var ErrGettingAPIKeys = errors.New("error getting API keys in database")
+var ErrRevokingAPIKey = errors.New("error revoking API key in database")
+var ErrAPIKeyDoesNotExist = errors.New("API key does not exist or is already revoked")
+var ErrAPIKeyExpirationTooLong = errors.New("API key expiration exceeds the maximum allowed")
+var ErrTooManyAPIKeys = errors.New("too many active API keys, revoke some before creating more")
+var ErrAPIKeyNotSigned = errors.New("an API key can only be created or revoked with a signed nonce")
+
+// sessions
+var ErrSessionsDisabled = errors.New("session tokens are not enabled")
+var ErrCreatingSession = errors.New("error creating session in database")
+var ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+var ErrRefreshingSession = errors.New("error refreshing session")
+var ErrRevokingSession = errors.New("error revoking session")
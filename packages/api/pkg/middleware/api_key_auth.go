@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/AssetPortal/assets-api/pkg/adapters/apikeys"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/go-chi/render"
+	"github.com/sirupsen/logrus"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// APIKeyAuth authenticates server to server requests with the X-API-Key header.
+// The principal is the owner of the key, restricted to the permissions of the key.
+type APIKeyAuth struct {
+	apiKeysRepo apikeys.Repository
+	networks    model.Networks
+}
+
+func NewAPIKeyAuth(apiKeysRepo apikeys.Repository) *APIKeyAuth {
+	return &APIKeyAuth{apiKeysRepo: apiKeysRepo, networks: model.DefaultNetworks()}
+}
+
+// WithNetworks sets the networks that the owners of the keys are encoded for, instead of the default ones.
+func (a *APIKeyAuth) WithNetworks(networks model.Networks) *APIKeyAuth {
+	a.networks = networks
+	return a
+}
+
+func (a *APIKeyAuth) Matches(r *http.Request) bool {
+	return r.Header.Get(apiKeyHeader) != ""
+}
+
+func (a *APIKeyAuth) Authenticate(w http.ResponseWriter, r *http.Request) (*model.Principal, bool) {
+	value := r.Header.Get(apiKeyHeader)
+	id, err := model.ParseAPIKeyID(value)
+	if err != nil {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("Invalid API key"))
+		return nil, false
+	}
+	key, err := a.apiKeysRepo.GetAPIKey(r.Context(), id)
+	if err != nil {
+		logrus.Errorf("Error retrieving API key: %s", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, model.NewResponseError("Cannot verify the API key"))
+		return nil, false
+	}
+	if key == nil || subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(model.HashSecret(value))) != 1 {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("Invalid API key"))
+		return nil, false
+	}
+	if !key.IsValid() {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("API key was revoked or expired"))
+		return nil, false
+	}
+	return &model.Principal{
+		Address:     key.Owner,
+		Network:     a.networks.AddressNetwork(key.Owner),
+		AuthMethod:  model.AUTH_METHOD_API_KEY,
+		APIKeyID:    key.ID,
+		Permissions: key.Permissions,
+	}, true
+}
+
+// RequirePermission rejects principals that are not allowed the permission.
+// Requests without a principal are left to the handlers.
+func RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if principal, ok := PrincipalFromContext(r.Context()); ok && !principal.Allows(permission) {
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, model.NewResponseError("API key does not have the '"+permission+"' permission"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiKeysMock "github.com/AssetPortal/assets-api/pkg/adapters/apikeys/mocks"
+	authMock "github.com/AssetPortal/assets-api/pkg/adapters/auth/mocks"
+	tokensMock "github.com/AssetPortal/assets-api/pkg/adapters/tokens/mocks"
+	"github.com/AssetPortal/assets-api/pkg/middleware"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/ggicci/httpin"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testAPIKeyID = "0123456789abcdef"
+	testAPIKey   = "ak_0123456789abcdef_secret"
+)
+
+func TestAPIKeyAuth(t *testing.T) {
+	revokedAt := time.Now()
+	tests := []struct {
+		name       string
+		header     string
+		key        *model.APIKey
+		permission string
+		wantStatus int
+	}{
+		{
+			name:       "valid key with permission",
+			header:     testAPIKey,
+			key:        &model.APIKey{ID: testAPIKeyID, Owner: "owner", KeyHash: model.HashSecret(testAPIKey), Permissions: []string{model.PERMISSION_UPDATE}, ExpiresAt: time.Now().Add(time.Hour)},
+			permission: model.PERMISSION_UPDATE,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "valid key without permission",
+			header:     testAPIKey,
+			key:        &model.APIKey{ID: testAPIKeyID, Owner: "owner", KeyHash: model.HashSecret(testAPIKey), Permissions: []string{model.PERMISSION_UPDATE}, ExpiresAt: time.Now().Add(time.Hour)},
+			permission: model.PERMISSION_DELETE,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "wrong secret",
+			header:     "ak_0123456789abcdef_other",
+			key:        &model.APIKey{ID: testAPIKeyID, Owner: "owner", KeyHash: model.HashSecret(testAPIKey), Permissions: []string{model.PERMISSION_UPDATE}, ExpiresAt: time.Now().Add(time.Hour)},
+			permission: model.PERMISSION_UPDATE,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "expired key",
+			header:     testAPIKey,
+			key:        &model.APIKey{ID: testAPIKeyID, Owner: "owner", KeyHash: model.HashSecret(testAPIKey), Permissions: []string{model.PERMISSION_UPDATE}, ExpiresAt: time.Now().Add(-time.Hour)},
+			permission: model.PERMISSION_UPDATE,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "revoked key",
+			header:     testAPIKey,
+			key:        &model.APIKey{ID: testAPIKeyID, Owner: "owner", KeyHash: model.HashSecret(testAPIKey), Permissions: []string{model.PERMISSION_UPDATE}, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt},
+			permission: model.PERMISSION_UPDATE,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown key",
+			header:     testAPIKey,
+			permission: model.PERMISSION_UPDATE,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "malformed key",
+			header:     "not-a-key",
+			permission: model.PERMISSION_UPDATE,
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeysRepoMock := new(apiKeysMock.Repository)
+			apiKeysRepoMock.On("GetAPIKey", mock.Anything, testAPIKeyID).Return(tt.key, nil).Maybe()
+			polkadotAuth := middleware.NewPolkadotAuth(new(tokensMock.Repository), new(authMock.Client), true, "").
+				WithProvider(middleware.NewAPIKeyAuth(apiKeysRepoMock))
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal, ok := middleware.PrincipalFromContext(r.Context())
+				assert.True(t, ok)
+				assert.Equal(t, "owner", principal.Address)
+				assert.Equal(t, model.AUTH_METHOD_API_KEY, principal.AuthMethod)
+				assert.Equal(t, testAPIKeyID, principal.APIKeyID)
+			})
+
+			router := chi.NewRouter()
+			router.With(
+				httpin.NewInput(model.AuthHeaders{}),
+			).With(polkadotAuth.Middleware).With(
+				middleware.RequirePermission(tt.permission),
+			).Put("/test", handler)
+
+			req := httptest.NewRequest(http.MethodPut, "/test", nil)
+			req.Header.Set("X-API-Key", tt.header)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestPolkadotAuthOptionalMiddleware(t *testing.T) {
+	apiKeysRepoMock := new(apiKeysMock.Repository)
+	apiKeysRepoMock.On("GetAPIKey", mock.Anything, testAPIKeyID).Return(&model.APIKey{
+		ID:          testAPIKeyID,
+		Owner:       "owner",
+		KeyHash:     model.HashSecret(testAPIKey),
+		Permissions: []string{model.PERMISSION_CREATE},
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}, nil)
+	polkadotAuth := middleware.NewPolkadotAuth(new(tokensMock.Repository), new(authMock.Client), true, "").
+		WithProvider(middleware.NewAPIKeyAuth(apiKeysRepoMock))
+
+	router := chi.NewRouter()
+	router.With(polkadotAuth.OptionalMiddleware).With(
+		middleware.RequirePermission(model.PERMISSION_UPLOAD),
+	).Post("/upload", func(w http.ResponseWriter, r *http.Request) {})
+
+	// Anonymous requests are let through.
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/upload", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// API keys must have the permission.
+	req := httptest.NewRequest(http.MethodPost, "/upload", nil)
+	req.Header.Set("X-API-Key", testAPIKey)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/go-chi/render"
+)
+
+// MaxDigestBodySize is the maximum size of a request body read by BodyDigest.
+const MaxDigestBodySize = 1 << 20
+
+type bodyDigestKey struct{}
+
+// BodyDigest computes the hex SHA-256 digest of the request body, which is then read again by the next handlers.
+func BodyDigest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxDigestBodySize))
+		if err != nil {
+			render.Status(r, http.StatusRequestEntityTooLarge)
+			render.JSON(w, r, model.NewResponseError("request body is too large"))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.Sum256(body)
+		ctx := context.WithValue(r.Context(), bodyDigestKey{}, hex.EncodeToString(sum[:]))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// BodyDigestFromContext returns the digest of the request body set by BodyDigest.
+func BodyDigestFromContext(ctx context.Context) string {
+	digest, _ := ctx.Value(bodyDigestKey{}).(string)
+	return digest
+}
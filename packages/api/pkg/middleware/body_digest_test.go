@@ -0,0 +1,42 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AssetPortal/assets-api/pkg/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyDigest(t *testing.T) {
+	var digest, body string
+	handler := middleware.BodyDigest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		digest = middleware.BodyDigestFromContext(r.Context())
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/assets/bulk", strings.NewReader(`{"items":[]}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"items":[]}`, body)
+	assert.Equal(t, "eef46741adfc3a9f76294d3b78f37a45f113092ac9d44ee77c7a038a88ff09a1", digest)
+}
+
+func TestBodyDigest_TooLarge(t *testing.T) {
+	handler := middleware.BodyDigest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("the handler should not be called")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/assets/bulk", strings.NewReader(strings.Repeat("a", middleware.MaxDigestBodySize+1)))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
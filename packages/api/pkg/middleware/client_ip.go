@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/httprate"
+)
+
+type clientIPKey struct{}
+
+// WithClientIP returns a copy of the context carrying the client IP.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIPFromContext returns the client IP set by the nonce limiter.
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// clientIP returns the IP of the client. Proxy headers are only used when trusted,
+// otherwise any client could pick its own key.
+func clientIP(r *http.Request, trustProxy bool) string {
+	var ip string
+	if trustProxy {
+		ip, _ = httprate.KeyByRealIP(r)
+	} else {
+		ip, _ = httprate.KeyByIP(r)
+	}
+	return ip
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/config"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/go-chi/httprate"
+	"github.com/go-chi/render"
+)
+
+// NonceLimiter throttles nonce issuance per client IP, and per address when one is given.
+// Each key is allowed a burst per second and a sustained rate per window.
+type NonceLimiter struct {
+	limiters   []*httprate.RateLimiter
+	trustProxy bool
+}
+
+func NewNonceLimiter(cfg config.NonceConfiguration) *NonceLimiter {
+	limiter := &NonceLimiter{trustProxy: cfg.TrustProxy}
+	if cfg.Burst > 0 {
+		limiter.limiters = append(limiter.limiters, newNonceRateLimiter(cfg.Burst, time.Second))
+	}
+	if cfg.RateLimit > 0 {
+		limiter.limiters = append(limiter.limiters, newNonceRateLimiter(cfg.RateLimit, cfg.RateWindow))
+	}
+	return limiter
+}
+
+func newNonceRateLimiter(limit int, window time.Duration) *httprate.RateLimiter {
+	return httprate.NewRateLimiter(
+		limit,
+		window,
+		httprate.WithLimitHandler(func(w http.ResponseWriter, r *http.Request) {
+			render.Status(r, http.StatusTooManyRequests)
+			render.JSON(w, r, model.NewResponseError(fmt.Sprintf("Too many nonce requests: max is %d per %s", limit, window)))
+		}),
+	)
+}
+
+func (l *NonceLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := clientIP(r, l.trustProxy)
+		keys := []string{"ip:" + ip}
+		if address := r.URL.Query().Get("address"); address != "" {
+			// An account is limited once, whatever network its address is encoded for.
+			if canonical, err := model.CanonicalAddress(address); err == nil {
+				address = canonical
+			}
+			keys = append(keys, "address:"+address)
+		}
+		for _, limiter := range l.limiters {
+			for _, key := range keys {
+				if limiter.RespondOnLimit(w, r, key) {
+					return
+				}
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(WithClientIP(r.Context(), ip)))
+	})
+}
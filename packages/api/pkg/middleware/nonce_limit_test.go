@@ -0,0 +1,110 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/config"
+	"github.com/AssetPortal/assets-api/pkg/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonceLimiter(t *testing.T) {
+	limiter := middleware.NewNonceLimiter(config.NonceConfiguration{
+		RateLimit:  3,
+		RateWindow: time.Minute,
+		Burst:      10,
+	})
+	var clientIP string
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientIP = middleware.ClientIPFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func(remoteAddr, address string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/nonce?address="+address, nil)
+		req.RemoteAddr = remoteAddr
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, request("10.0.0.1:1234", "").Code)
+	}
+	assert.Equal(t, "10.0.0.1", clientIP)
+
+	// The IP is out of requests.
+	rr := request("10.0.0.1:1234", "")
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "60", rr.Header().Get("Retry-After"))
+
+	// Other clients are not affected.
+	assert.Equal(t, http.StatusOK, request("10.0.0.2:1234", "addressA").Code)
+
+	// The address is limited across IPs.
+	assert.Equal(t, http.StatusOK, request("10.0.0.3:1234", "addressA").Code)
+	assert.Equal(t, http.StatusOK, request("10.0.0.4:1234", "addressA").Code)
+	assert.Equal(t, http.StatusTooManyRequests, request("10.0.0.5:1234", "addressA").Code)
+}
+
+func TestNonceLimiter_AddressEncodings(t *testing.T) {
+	limiter := middleware.NewNonceLimiter(config.NonceConfiguration{
+		RateLimit:  2,
+		RateWindow: time.Minute,
+	})
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func(remoteAddr, address string) int {
+		req := httptest.NewRequest(http.MethodGet, "/nonce?address="+address, nil)
+		req.RemoteAddr = remoteAddr
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	// The Polkadot and Kusama addresses of an account share its limit.
+	assert.Equal(t, http.StatusOK, request("10.0.0.1:1234", polkadotAddress))
+	assert.Equal(t, http.StatusOK, request("10.0.0.2:1234", "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F"))
+	assert.Equal(t, http.StatusTooManyRequests, request("10.0.0.3:1234", canonicalAddress))
+}
+
+func TestNonceLimiter_Burst(t *testing.T) {
+	limiter := middleware.NewNonceLimiter(config.NonceConfiguration{
+		RateLimit:  100,
+		RateWindow: time.Minute,
+		Burst:      2,
+	})
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	codes := []int{}
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/nonce", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		codes = append(codes, rr.Code)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+}
+
+func TestNonceLimiter_TrustProxy(t *testing.T) {
+	limiter := middleware.NewNonceLimiter(config.NonceConfiguration{TrustProxy: true})
+	var clientIP string
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientIP = middleware.ClientIPFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/nonce", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Real-IP", "192.168.1.1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "192.168.1.1", clientIP)
+}
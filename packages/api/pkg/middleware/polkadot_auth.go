@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"encoding/hex"
 	"net/http"
+	"strings"
 
 	"github.com/AssetPortal/assets-api/pkg/adapters/auth"
+	"github.com/AssetPortal/assets-api/pkg/adapters/sessions"
 	"github.com/AssetPortal/assets-api/pkg/adapters/tokens"
 	"github.com/AssetPortal/assets-api/pkg/model"
 	"github.com/ggicci/httpin"
@@ -11,78 +14,283 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// Provider authenticates the requests that carry its kind of credentials.
+type Provider interface {
+	// Matches returns true if the request carries credentials for the provider.
+	Matches(r *http.Request) bool
+	// Authenticate returns the principal of the request.
+	// On failure it writes the error response and returns false.
+	Authenticate(w http.ResponseWriter, r *http.Request) (*model.Principal, bool)
+}
+
 type PolkadotAuth struct {
-	tokensRepo tokens.Repository
-	authClient auth.Client
-	enabled    bool
+	providers     []Provider
+	tokensRepo    tokens.Repository
+	authClient    auth.Client
+	sessionsRepo  sessions.Repository
+	sessionSecret []byte
+	siwsDomain    string
+	siwsURI       string
+	networks      model.Networks
+	enabled       bool
+	devAddress    string
 }
 
 // NewPolkadotAuth initializes PolkadotAuth with a TokenRepository.
-func NewPolkadotAuth(tokensRepo tokens.Repository, authClient auth.Client, enabled bool) *PolkadotAuth {
+// When it is disabled, every request is authenticated as devAddress.
+func NewPolkadotAuth(tokensRepo tokens.Repository, authClient auth.Client, enabled bool, devAddress string) *PolkadotAuth {
 	return &PolkadotAuth{
 		tokensRepo: tokensRepo,
 		authClient: authClient,
+		networks:   model.DefaultNetworks(),
 		enabled:    enabled,
+		devAddress: devAddress,
 	}
 }
 
+// WithSessions accepts bearer tokens issued by /auth/session besides signatures.
+func (p *PolkadotAuth) WithSessions(sessionsRepo sessions.Repository, secret string) *PolkadotAuth {
+	p.sessionsRepo = sessionsRepo
+	p.sessionSecret = []byte(secret)
+	return p
+}
+
+// WithSIWS accepts Sign-In-With-Substrate messages issued for the domain and URI.
+func (p *PolkadotAuth) WithSIWS(domain, uri string) *PolkadotAuth {
+	p.siwsDomain = domain
+	p.siwsURI = uri
+	return p
+}
+
+// WithNetworks sets the networks that the addresses of the principals are encoded for, instead of the default ones.
+func (p *PolkadotAuth) WithNetworks(networks model.Networks) *PolkadotAuth {
+	p.networks = networks
+	return p
+}
+
+// WithProvider accepts the credentials of another provider.
+// Providers are tried in order before session tokens and signatures.
+func (p *PolkadotAuth) WithProvider(provider Provider) *PolkadotAuth {
+	p.providers = append(p.providers, provider)
+	return p
+}
+
 // Middleware for Polkadot authentication.
+// It sets the verified signer, or the principal of the first matching provider, as the request principal.
 func (p *PolkadotAuth) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !p.enabled {
-			next.ServeHTTP(w, r)
-		} else {
-			headers := r.Context().Value(httpin.Input).(*model.AuthHeaders)
-			address := r.Header.Get("X-Address")
-			signature := r.Header.Get("X-Signature")
-			message := r.Header.Get("X-Message")
-
-			if address == "" || signature == "" || message == "" {
-				render.JSON(w, r, model.NewResponseError("Missing authentication headers"))
-				return
-			}
-			// Verify the token
-			dbToken, err := p.tokensRepo.GetToken(r.Context(), headers.Message)
-			if err != nil {
-				logrus.Errorf("Error retrieving token: %s", err)
-				render.Status(r, http.StatusInternalServerError)
-				render.JSON(w, r, model.NewResponseError("Cannot verify the token"))
-				return
-			}
-			if dbToken == nil {
-				render.Status(r, http.StatusBadRequest)
-				render.JSON(w, r, model.NewResponseError("Message was not generated with /nonce"))
-				return
-			}
-			if !dbToken.IsValid() {
+			if p.devAddress == "" {
 				render.Status(r, http.StatusUnauthorized)
-				render.JSON(w, r, model.NewResponseError("Invalid or expired token"))
+				render.JSON(w, r, model.NewResponseError("Authentication is disabled and no dev address is configured"))
 				return
 			}
-
-			// Verify the Polkadot signature
-			auth, err := p.authClient.VerifySignature(r.Context(), headers.Message, headers.Address, headers.Signature)
+			principal, err := model.NewAddressPrincipal(p.devAddress, model.AUTH_METHOD_DEV, p.networks)
 			if err != nil {
-				logrus.Errorf("Error verifying the signature: %s", err)
-				render.Status(r, http.StatusInternalServerError)
-				render.JSON(w, r, model.NewResponseError("Cannot verify signature now"))
-				return
-			}
-			if !auth.OK {
 				render.Status(r, http.StatusUnauthorized)
-				render.JSON(w, r, model.NewResponseError("Invalid authentication: "+auth.Message))
+				render.JSON(w, r, model.NewResponseError("Authentication is disabled and the dev address is invalid"))
 				return
 			}
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+			return
+		}
 
-			// Mark the token as used
-			if err := p.tokensRepo.MarkTokenAsUsed(r.Context(), headers.Message); err != nil {
-				logrus.Errorf("Error marking token as used: %s", err)
-				render.Status(r, http.StatusInternalServerError)
-				render.JSON(w, r, model.NewResponseError("Failed to mark token as used"))
-				return
-			}
+		var principal *model.Principal
+		var ok bool
+		if provider := p.matchingProvider(r); provider != nil {
+			principal, ok = provider.Authenticate(w, r)
+		} else if token, found := bearerToken(r); found {
+			principal, ok = p.authenticateSession(w, r, token)
+		} else {
+			principal, ok = p.authenticateSignature(w, r)
+		}
+		if !ok {
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+	})
+}
 
+// authenticateSignature verifies the X-Signature, X-Address and X-Message headers.
+// X-Message is either the nonce or a 0x prefixed hex encoded sign in message.
+func (p *PolkadotAuth) authenticateSignature(w http.ResponseWriter, r *http.Request) (*model.Principal, bool) {
+	headers := r.Context().Value(httpin.Input).(*model.AuthHeaders)
+	address := r.Header.Get("X-Address")
+	signature := r.Header.Get("X-Signature")
+	message := r.Header.Get("X-Message")
+
+	if address == "" || signature == "" || message == "" {
+		render.JSON(w, r, model.NewResponseError("Missing authentication headers"))
+		return nil, false
+	}
+	nonce := headers.Message
+	var siws *model.SIWSMessage
+	if text, ok := decodeSIWSMessage(headers.Message); ok {
+		parsed, err := model.ParseSIWSMessage(text)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, model.NewResponseError("Invalid sign in message: "+err.Error()))
+			return nil, false
+		}
+		siws = parsed
+		nonce = parsed.Nonce
+	}
+
+	// Verify the token
+	dbToken, err := p.tokensRepo.GetToken(r.Context(), nonce)
+	if err != nil {
+		logrus.Errorf("Error retrieving token: %s", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, model.NewResponseError("Cannot verify the token"))
+		return nil, false
+	}
+	if dbToken == nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, model.NewResponseError("Message was not generated with /nonce"))
+		return nil, false
+	}
+	if !dbToken.IsValid() {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("Invalid or expired token"))
+		return nil, false
+	}
+	if !dbToken.IsIssuedFor(address, r.Method, r.URL.Path) {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("Token was not issued for this address and action"))
+		return nil, false
+	}
+	if siws != nil {
+		if p.siwsDomain == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, model.NewResponseError("Sign in messages are not enabled"))
+			return nil, false
+		}
+		expected := model.NewSIWSMessage(dbToken, p.siwsDomain, p.siwsURI, p.networks.AddressNetwork(address), dbToken.Statement())
+		if err := siws.Verify(expected); err != nil {
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, model.NewResponseError("Invalid sign in message: "+err.Error()))
+			return nil, false
+		}
+	}
+
+	// Verify the Polkadot signature
+	result, err := p.authClient.VerifySignature(r.Context(), headers.Message, headers.Address, headers.Signature)
+	if err != nil {
+		logrus.Errorf("Error verifying the signature: %s", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, model.NewResponseError("Cannot verify signature now"))
+		return nil, false
+	}
+	if !result.OK {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("Invalid authentication: "+result.Message))
+		return nil, false
+	}
+	principal, err := model.NewAddressPrincipal(headers.Address, model.AUTH_METHOD_SIGNATURE, p.networks)
+	if err != nil {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("Invalid authentication: "+err.Error()))
+		return nil, false
+	}
+
+	// Consume the token, only one request can do it
+	consumed, err := p.tokensRepo.ConsumeToken(r.Context(), nonce)
+	if err != nil {
+		logrus.Errorf("Error consuming token: %s", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, model.NewResponseError("Failed to mark token as used"))
+		return nil, false
+	}
+	if consumed == nil {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("Invalid or expired token"))
+		return nil, false
+	}
+
+	principal.KeyType = result.KeyType
+	principal.Nonce = nonce
+	if dbToken.Digest != nil {
+		principal.Digest = *dbToken.Digest
+	}
+	return principal, true
+}
+
+// authenticateSession verifies a bearer access token and that its session was not revoked.
+func (p *PolkadotAuth) authenticateSession(w http.ResponseWriter, r *http.Request, token string) (*model.Principal, bool) {
+	if p.sessionsRepo == nil || len(p.sessionSecret) == 0 {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("Session tokens are not enabled"))
+		return nil, false
+	}
+	claims, err := model.ParseSessionToken(token, p.sessionSecret)
+	if err != nil {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("Invalid or expired session token"))
+		return nil, false
+	}
+	session, err := p.sessionsRepo.GetSession(r.Context(), claims.ID)
+	if err != nil {
+		logrus.Errorf("Error retrieving session: %s", err)
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, model.NewResponseError("Cannot verify the session"))
+		return nil, false
+	}
+	if session == nil || !session.IsValid() || session.Address != claims.Subject {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("Session was revoked or expired"))
+		return nil, false
+	}
+	return &model.Principal{
+		Address:    session.Address,
+		Network:    session.Network,
+		KeyType:    session.KeyType,
+		AuthMethod: model.AUTH_METHOD_SESSION,
+		SessionID:  session.ID,
+		SS58Prefix: p.networks.Prefix(session.Network),
+	}, true
+}
+
+// OptionalMiddleware authenticates the request only when a provider matches it, so that public routes can be scoped too.
+func (p *PolkadotAuth) OptionalMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provider := p.matchingProvider(r)
+		if !p.enabled || provider == nil {
 			next.ServeHTTP(w, r)
+			return
+		}
+		principal, ok := provider.Authenticate(w, r)
+		if !ok {
+			return
 		}
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
 	})
 }
+
+func (p *PolkadotAuth) matchingProvider(r *http.Request) Provider {
+	for _, provider := range p.providers {
+		if provider.Matches(r) {
+			return provider
+		}
+	}
+	return nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) < len("Bearer ") || !strings.EqualFold(authorization[:len("Bearer ")], "Bearer ") {
+		return "", false
+	}
+	return strings.TrimSpace(authorization[len("Bearer "):]), true
+}
+
+// decodeSIWSMessage returns the text of a 0x prefixed hex encoded message.
+func decodeSIWSMessage(message string) (string, bool) {
+	if !strings.HasPrefix(message, "0x") {
+		return "", false
+	}
+	text, err := hex.DecodeString(message[2:])
+	if err != nil {
+		return "", false
+	}
+	return string(text), true
+}
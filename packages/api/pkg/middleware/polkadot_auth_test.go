@@ -1,11 +1,17 @@
 package middleware_test
 
 import (
+	"context"
+	"encoding/hex"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	authMock "github.com/AssetPortal/assets-api/pkg/adapters/auth/mocks"
+	sessionsMock "github.com/AssetPortal/assets-api/pkg/adapters/sessions/mocks"
 	tokensMock "github.com/AssetPortal/assets-api/pkg/adapters/tokens/mocks"
 	"github.com/AssetPortal/assets-api/pkg/middleware"
 	"github.com/AssetPortal/assets-api/pkg/model"
@@ -16,30 +22,54 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// Addresses of the //Alice development account
+const (
+	polkadotAddress  = "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5"
+	canonicalAddress = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+)
+
 func TestPolkadotAuthMiddleware(t *testing.T) {
 	tokensRepoMock := new(tokensMock.Repository)
 	authClientMock := new(authMock.Client)
 
 	headers := &model.AuthHeaders{
 		Signature: "valid-signature",
-		Address:   "valid-address",
+		Address:   polkadotAddress,
 		Message:   "valid-message",
 	}
 
-	polkadotAuth := middleware.NewPolkadotAuth(tokensRepoMock, authClientMock, true)
+	polkadotAuth := middleware.NewPolkadotAuth(tokensRepoMock, authClientMock, true, "")
 
-	tokensRepoMock.On("GetToken", mock.Anything, "valid-message").Return(&model.Token{Token: "valid-message"}, nil)
+	digest := "eef46741adfc3a9f76294d3b78f37a45f113092ac9d44ee77c7a038a88ff09a1"
+	token := &model.Token{
+		Token:     "valid-message",
+		Address:   polkadotAddress,
+		Method:    http.MethodGet,
+		Path:      "/test",
+		Digest:    &digest,
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	tokensRepoMock.On("GetToken", mock.Anything, "valid-message").Return(token, nil)
 
-	authClientMock.On("VerifySignature", mock.Anything, "valid-message", "valid-address", "valid-signature").
-		Return(&model.Auth{OK: true}, nil)
+	authClientMock.On("VerifySignature", mock.Anything, "valid-message", polkadotAddress, "valid-signature").
+		Return(&model.Auth{OK: true, KeyType: model.KEY_TYPE_SR25519}, nil)
 
-	tokensRepoMock.On("MarkTokenAsUsed", mock.Anything, "valid-message").Return(nil)
+	tokensRepoMock.On("ConsumeToken", mock.Anything, "valid-message").Return(token, nil)
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.NotNil(t, headers)
 		assert.Equal(t, "valid-signature", headers.Signature)
-		assert.Equal(t, "valid-address", headers.Address)
+		assert.Equal(t, polkadotAddress, headers.Address)
 		assert.Equal(t, "valid-message", headers.Message)
+		principal, ok := middleware.PrincipalFromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, canonicalAddress, principal.Address)
+		assert.Equal(t, model.POLKADOT, principal.Network)
+		assert.Equal(t, uint16(0), *principal.SS58Prefix)
+		assert.Equal(t, model.KEY_TYPE_SR25519, principal.KeyType)
+		assert.Equal(t, model.AUTH_METHOD_SIGNATURE, principal.AuthMethod)
+		assert.Equal(t, digest, principal.Digest)
+		assert.Equal(t, "valid-message", principal.Nonce)
 		render.JSON(w, r, model.NewResponseError("Success"))
 	})
 
@@ -49,7 +79,7 @@ func TestPolkadotAuthMiddleware(t *testing.T) {
 	).With(polkadotAuth.Middleware).Get("/test", handler)
 
 	req := httptest.NewRequest(http.MethodGet, "/test", nil)
-	req.Header.Set("X-Address", "valid-address")
+	req.Header.Set("X-Address", polkadotAddress)
 	req.Header.Set("X-Signature", "valid-signature")
 	req.Header.Set("X-Message", "valid-message")
 
@@ -67,8 +97,13 @@ func TestPolkadotAuthMiddleware(t *testing.T) {
 func TestPolkadotAuthMiddlewareDisabled(t *testing.T) {
 	tokensRepoMock := new(tokensMock.Repository)
 	authClientMock := new(authMock.Client)
-	polkadotAuth := middleware.NewPolkadotAuth(tokensRepoMock, authClientMock, false)
+	polkadotAuth := middleware.NewPolkadotAuth(tokensRepoMock, authClientMock, false, polkadotAddress)
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := middleware.PrincipalFromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, canonicalAddress, principal.Address)
+		assert.Equal(t, model.POLKADOT, principal.Network)
+		assert.Equal(t, model.AUTH_METHOD_DEV, principal.AuthMethod)
 		render.JSON(w, r, model.NewResponseError("Success"))
 	})
 
@@ -78,6 +113,7 @@ func TestPolkadotAuthMiddlewareDisabled(t *testing.T) {
 	).With(polkadotAuth.Middleware).Get("/test", handler)
 
 	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("X-Address", "spoofed-address")
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
 
@@ -87,3 +123,289 @@ func TestPolkadotAuthMiddlewareDisabled(t *testing.T) {
 	tokensRepoMock.AssertExpectations(t)
 	authClientMock.AssertExpectations(t)
 }
+
+func TestPolkadotAuthMiddlewareDisabledWithoutDevAddress(t *testing.T) {
+	tokensRepoMock := new(tokensMock.Repository)
+	authClientMock := new(authMock.Client)
+	polkadotAuth := middleware.NewPolkadotAuth(tokensRepoMock, authClientMock, false, "")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler must not be called")
+	})
+
+	router := chi.NewRouter()
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(polkadotAuth.Middleware).Get("/test", handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("X-Address", "spoofed-address")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestPolkadotAuthMiddlewareTokenNotIssuedForRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		token *model.Token
+	}{
+		{
+			name: "different address",
+			token: &model.Token{
+				Token:     "valid-message",
+				Address:   "other-address",
+				Method:    http.MethodGet,
+				Path:      "/test",
+				ExpiresAt: time.Now().Add(time.Minute),
+			},
+		},
+		{
+			name: "different method",
+			token: &model.Token{
+				Token:     "valid-message",
+				Address:   polkadotAddress,
+				Method:    http.MethodDelete,
+				Path:      "/test",
+				ExpiresAt: time.Now().Add(time.Minute),
+			},
+		},
+		{
+			name: "different path",
+			token: &model.Token{
+				Token:     "valid-message",
+				Address:   polkadotAddress,
+				Method:    http.MethodGet,
+				Path:      "/other",
+				ExpiresAt: time.Now().Add(time.Minute),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokensRepoMock := new(tokensMock.Repository)
+			authClientMock := new(authMock.Client)
+			polkadotAuth := middleware.NewPolkadotAuth(tokensRepoMock, authClientMock, true, "")
+
+			tokensRepoMock.On("GetToken", mock.Anything, "valid-message").Return(tt.token, nil)
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("handler must not be called")
+			})
+
+			router := chi.NewRouter()
+			router.With(
+				httpin.NewInput(model.AuthHeaders{}),
+			).With(polkadotAuth.Middleware).Get("/test", handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("X-Address", polkadotAddress)
+			req.Header.Set("X-Signature", "valid-signature")
+			req.Header.Set("X-Message", "valid-message")
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+			tokensRepoMock.AssertExpectations(t)
+			authClientMock.AssertNotCalled(t, "VerifySignature")
+		})
+	}
+}
+
+func TestPolkadotAuthMiddlewareSession(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+	revokedAt := now
+	session := &model.Session{ID: "session123", Address: polkadotAddress, ExpiresAt: now.Add(time.Hour)}
+	validToken, _ := model.NewSessionClaims(session, now, now.Add(time.Minute)).Sign(secret)
+	expiredToken, _ := model.NewSessionClaims(session, now.Add(-time.Hour), now.Add(-time.Minute)).Sign(secret)
+	forgedToken, _ := model.NewSessionClaims(session, now, now.Add(time.Minute)).Sign([]byte("other"))
+
+	tests := []struct {
+		name     string
+		token    string
+		session  *model.Session
+		expected int
+	}{
+		{name: "valid session", token: validToken, session: session, expected: http.StatusOK},
+		{name: "revoked session", token: validToken, session: &model.Session{ID: "session123", Address: polkadotAddress, ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}, expected: http.StatusUnauthorized},
+		{name: "unknown session", token: validToken, session: nil, expected: http.StatusUnauthorized},
+		{name: "expired token", token: expiredToken, expected: http.StatusUnauthorized},
+		{name: "forged token", token: forgedToken, expected: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokensRepoMock := new(tokensMock.Repository)
+			authClientMock := new(authMock.Client)
+			sessionsRepoMock := new(sessionsMock.Repository)
+			polkadotAuth := middleware.NewPolkadotAuth(tokensRepoMock, authClientMock, true, "").
+				WithSessions(sessionsRepoMock, string(secret))
+
+			sessionsRepoMock.On("GetSession", mock.Anything, "session123").Return(tt.session, nil).Maybe()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal, ok := middleware.PrincipalFromContext(r.Context())
+				assert.True(t, ok)
+				assert.Equal(t, polkadotAddress, principal.Address)
+				assert.Equal(t, model.AUTH_METHOD_SESSION, principal.AuthMethod)
+				assert.Equal(t, "session123", principal.SessionID)
+				render.JSON(w, r, model.NewResponseEmpty())
+			})
+
+			router := chi.NewRouter()
+			router.With(
+				httpin.NewInput(model.AuthHeaders{}),
+			).With(polkadotAuth.Middleware).Get("/test", handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expected, rr.Code)
+			tokensRepoMock.AssertExpectations(t)
+			authClientMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPolkadotAuthMiddlewareSIWS(t *testing.T) {
+	address := polkadotAddress
+	now := time.Now()
+	token := &model.Token{
+		Token:     "valid-nonce",
+		Address:   address,
+		Action:    model.ACTION_CREATE_ASSET,
+		Method:    http.MethodPost,
+		Path:      "/assets",
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Minute),
+	}
+	valid := model.NewSIWSMessage(token, "assetportal.xyz", "https://assetportal.xyz", model.POLKADOT, token.Statement())
+	phishing := *valid
+	phishing.Domain = "phishing.xyz"
+
+	tests := []struct {
+		name     string
+		message  *model.SIWSMessage
+		expected int
+	}{
+		{name: "valid message", message: valid, expected: http.StatusOK},
+		{name: "other domain", message: &phishing, expected: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokensRepoMock := new(tokensMock.Repository)
+			authClientMock := new(authMock.Client)
+			polkadotAuth := middleware.NewPolkadotAuth(tokensRepoMock, authClientMock, true, "").
+				WithSIWS("assetportal.xyz", "https://assetportal.xyz")
+
+			message := "0x" + hex.EncodeToString([]byte(tt.message.String()))
+			tokensRepoMock.On("GetToken", mock.Anything, "valid-nonce").Return(token, nil)
+			authClientMock.On("VerifySignature", mock.Anything, message, address, "valid-signature").
+				Return(&model.Auth{OK: true}, nil).Maybe()
+			tokensRepoMock.On("ConsumeToken", mock.Anything, "valid-nonce").Return(token, nil).Maybe()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				render.JSON(w, r, model.NewResponseEmpty())
+			})
+
+			router := chi.NewRouter()
+			router.With(
+				httpin.NewInput(model.AuthHeaders{}),
+			).With(polkadotAuth.Middleware).Post("/assets", handler)
+
+			req := httptest.NewRequest(http.MethodPost, "/assets", nil)
+			req.Header.Set("X-Address", address)
+			req.Header.Set("X-Signature", "valid-signature")
+			req.Header.Set("X-Message", message)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expected, rr.Code)
+			tokensRepoMock.AssertExpectations(t)
+			authClientMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPolkadotAuthMiddlewareConcurrentNonce(t *testing.T) {
+	const requests = 20
+
+	tokensRepoMock := new(tokensMock.Repository)
+	authClientMock := new(authMock.Client)
+	polkadotAuth := middleware.NewPolkadotAuth(tokensRepoMock, authClientMock, true, "")
+
+	token := &model.Token{
+		Token:     "valid-message",
+		Address:   polkadotAddress,
+		Method:    http.MethodGet,
+		Path:      "/test",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	tokensRepoMock.On("GetToken", mock.Anything, "valid-message").Return(token, nil)
+	authClientMock.On("VerifySignature", mock.Anything, "valid-message", polkadotAddress, "valid-signature").
+		Return(&model.Auth{OK: true}, nil)
+
+	// Behaves like the conditional UPDATE: only the first caller consumes the token.
+	var mu sync.Mutex
+	used := false
+	tokensRepoMock.On("ConsumeToken", mock.Anything, "valid-message").
+		Return(func(_ context.Context, _ string) (*model.Token, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if used {
+				return nil, nil
+			}
+			used = true
+			return token, nil
+		})
+
+	var handled atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handled.Add(1)
+		render.JSON(w, r, model.NewResponseEmpty())
+	})
+
+	router := chi.NewRouter()
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(polkadotAuth.Middleware).Get("/test", handler)
+
+	codes := make(chan int, requests)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("X-Address", polkadotAddress)
+			req.Header.Set("X-Signature", "valid-signature")
+			req.Header.Set("X-Message", "valid-message")
+			rr := httptest.NewRecorder()
+			<-start
+			router.ServeHTTP(rr, req)
+			codes <- rr.Code
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(codes)
+
+	succeeded := 0
+	for code := range codes {
+		if code == http.StatusOK {
+			succeeded++
+		} else {
+			assert.Equal(t, http.StatusUnauthorized, code)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, int32(1), handled.Load())
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+)
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of the context carrying the authenticated principal.
+func WithPrincipal(ctx context.Context, principal *model.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal set by an authentication middleware.
+func PrincipalFromContext(ctx context.Context) (*model.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*model.Principal)
+	return principal, ok && principal != nil
+}
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// API keys have the form ak_<id>_<secret>. Only the hash of the whole key is stored.
+const apiKeyPrefix = "ak_"
+
+var apiKeyIDPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// APIKey authenticates server to server integrations on behalf of an owner.
+type APIKey struct {
+	bun.BaseModel `bun:"table:api_keys,alias:k"`
+	ID            string     `bun:"id,pk" json:"id"`
+	Owner         string     `bun:"owner,notnull" json:"owner"`
+	Name          string     `bun:"name" json:"name"`
+	KeyHash       string     `bun:"key_hash,notnull" json:"-"`
+	Permissions   []string   `bun:"permissions,array" json:"permissions"`
+	CreatedAt     time.Time  `bun:"created_at" json:"created_at"`
+	ExpiresAt     time.Time  `bun:"expires_at" json:"expires_at"`
+	RevokedAt     *time.Time `bun:"revoked_at" json:"revoked_at,omitempty"`
+}
+
+func (k *APIKey) IsValid() bool {
+	return k.RevokedAt == nil && time.Now().Before(k.ExpiresAt)
+}
+
+// NewAPIKeyValue returns the key given to the client from its id and secret.
+func NewAPIKeyValue(id, secret string) string {
+	return apiKeyPrefix + id + "_" + secret
+}
+
+// ParseAPIKeyID returns the id of an API key, without checking the secret.
+func ParseAPIKeyID(key string) (string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(key, apiKeyPrefix), "_", 2)
+	if !strings.HasPrefix(key, apiKeyPrefix) || len(parts) != 2 || parts[1] == "" {
+		return "", errors.New("malformed API key")
+	}
+	if err := validateAPIKeyID(parts[0]); err != nil {
+		return "", err
+	}
+	return parts[0], nil
+}
+
+// CreatedAPIKey is returned once when a key is created. The key cannot be retrieved later.
+type CreatedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+var permissions = []string{PERMISSION_CREATE, PERMISSION_DELETE, PERMISSION_RESTORE, PERMISSION_UPDATE, PERMISSION_UPLOAD}
+
+func validateAPIKeyID(id string) error {
+	if !apiKeyIDPattern.MatchString(id) {
+		return errors.New("API key id is invalid")
+	}
+	return nil
+}
+
+type NewAPIKey struct {
+	Name        string    `json:"name"`
+	Permissions []string  `json:"permissions"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type CreateAPIKeyInput struct {
+	NewAPIKey `in:"body=json;nonzero"`
+}
+
+func (c *CreateAPIKeyInput) Validate() error {
+	if len(c.Name) > 100 {
+		return errors.New("name exceeds the maximum length of 100 characters")
+	}
+	if len(c.Permissions) == 0 {
+		return errors.New("permissions are required")
+	}
+	for _, permission := range c.Permissions {
+		if !slices.Contains(permissions, permission) {
+			return fmt.Errorf("permissions must be some of: %s", strings.Join(permissions, ", "))
+		}
+	}
+	if !c.ExpiresAt.After(time.Now()) {
+		return errors.New("expires_at must be in the future")
+	}
+	return nil
+}
+
+type RevokeAPIKeyInput struct {
+	ID string `in:"path=id"`
+}
+
+func (c *RevokeAPIKeyInput) Validate() error {
+	return validateAPIKeyID(c.ID)
+}
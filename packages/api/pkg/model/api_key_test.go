@@ -0,0 +1,55 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAPIKeyID(t *testing.T) {
+	id, err := model.ParseAPIKeyID(model.NewAPIKeyValue("0123456789abcdef", "secret"))
+	assert.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", id)
+
+	for _, key := range []string{"", "ak_", "ak_0123456789abcdef", "ak_0123456789abcdef_", "xx_0123456789abcdef_secret", "ak_short_secret"} {
+		_, err := model.ParseAPIKeyID(key)
+		assert.Error(t, err, key)
+	}
+}
+
+func TestCreateAPIKeyInputValidation(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	tests := []struct {
+		name    string
+		input   model.NewAPIKey
+		wantErr bool
+	}{
+		{name: "valid", input: model.NewAPIKey{Name: "sync", Permissions: []string{model.PERMISSION_CREATE, model.PERMISSION_UPLOAD}, ExpiresAt: future}},
+		{name: "restore", input: model.NewAPIKey{Permissions: []string{model.PERMISSION_RESTORE}, ExpiresAt: future}},
+		{name: "no permissions", input: model.NewAPIKey{ExpiresAt: future}, wantErr: true},
+		{name: "unknown permission", input: model.NewAPIKey{Permissions: []string{"admin"}, ExpiresAt: future}, wantErr: true},
+		{name: "expired", input: model.NewAPIKey{Permissions: []string{model.PERMISSION_CREATE}, ExpiresAt: time.Now().Add(-time.Hour)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := model.CreateAPIKeyInput{NewAPIKey: tt.input}
+			err := input.Validate()
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestPrincipal_Allows(t *testing.T) {
+	wallet := &model.Principal{AuthMethod: model.AUTH_METHOD_SIGNATURE}
+	assert.True(t, wallet.Allows(model.PERMISSION_DELETE))
+
+	apiKey := &model.Principal{AuthMethod: model.AUTH_METHOD_API_KEY, Permissions: []string{model.PERMISSION_UPDATE}}
+	assert.True(t, apiKey.Allows(model.PERMISSION_UPDATE))
+	assert.False(t, apiKey.Allows(model.PERMISSION_DELETE))
+
+	// Restoring is not deleting.
+	apiKey = &model.Principal{AuthMethod: model.AUTH_METHOD_API_KEY, Permissions: []string{model.PERMISSION_DELETE}}
+	assert.False(t, apiKey.Allows(model.PERMISSION_RESTORE))
+}
@@ -1,18 +1,133 @@
 package model
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
 const MAX_LIMIT = 100
 
+// Directions of an order key
+const ORDER_ASC = "asc"
+const ORDER_DESC = "desc"
+
+// Order is a list of fields with an optional direction, such as "created_at:desc,id:asc".
 type Order struct {
 	Order     *string `in:"query=order"`
 	Ascending *bool   `in:"query=ascending"`
 }
 
+// IsAscending returns the direction of the fields without one, ascending by default.
+func (o *Order) IsAscending() bool {
+	return o.Ascending == nil || *o.Ascending
+}
+
+// OrderKey is one of the fields of an order.
+type OrderKey struct {
+	Field     string
+	Ascending bool
+}
+
+func (k OrderKey) String() string {
+	if k.Ascending {
+		return k.Field + ":" + ORDER_ASC
+	}
+	return k.Field + ":" + ORDER_DESC
+}
+
+// Keys parses the order. Its fields must be keys of columns, and appear once.
+func (o *Order) Keys(columns map[string]string) ([]OrderKey, error) {
+	if o.Order == nil {
+		return nil, nil
+	}
+	var keys []OrderKey
+	seen := map[string]bool{}
+	for _, part := range strings.Split(*o.Order, ",") {
+		field, direction, hasDirection := strings.Cut(strings.TrimSpace(part), ":")
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("order fields are: %s", strings.Join(sortedKeys(columns), ", "))
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("order field '%s' is repeated", field)
+		}
+		seen[field] = true
+
+		key := OrderKey{Field: field, Ascending: o.IsAscending()}
+		if hasDirection {
+			switch strings.ToLower(direction) {
+			case ORDER_ASC:
+				key.Ascending = true
+			case ORDER_DESC:
+				key.Ascending = false
+			default:
+				return nil, fmt.Errorf("order direction of '%s' must be %s or %s", field, ORDER_ASC, ORDER_DESC)
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// OrderString returns the order of the keys in the format of the order argument.
+func OrderString(keys []OrderKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// Fields is the list of fields of a response, such as "id,image". All fields are returned by default.
+type Fields struct {
+	Fields *string `in:"query=fields"`
+}
+
+// Parse returns the fields, which must be keys of columns, without duplicates.
+func (f *Fields) Parse(columns map[string]string) ([]string, error) {
+	if f.Fields == nil {
+		return nil, nil
+	}
+	var fields []string
+	seen := map[string]bool{}
+	for _, field := range strings.Split(*f.Fields, ",") {
+		field = strings.TrimSpace(field)
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("fields are: %s", strings.Join(sortedKeys(columns), ", "))
+		}
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+func sortedKeys(columns map[string]string) []string {
+	fields := make([]string, 0, len(columns))
+	for field := range columns {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Pagination is either by offset or by cursor. The cursor is the next_cursor of the previous page.
 type Pagination struct {
-	Limit  *int `in:"query=limit"`
-	Offset *int `in:"query=offset"`
+	Limit  *int    `in:"query=limit"`
+	Offset *int    `in:"query=offset"`
+	Cursor *string `in:"query=cursor"`
 }
 
-func (p *Pagination) Validate() {
+// Validate sets the default limit and offset, and caps the limit at MAX_LIMIT.
+func (p *Pagination) Validate() error {
+	if p.Limit != nil && *p.Limit < 1 {
+		return errors.New("limit must be at least 1")
+	}
+	if p.Offset != nil && *p.Offset < 0 {
+		return errors.New("offset cannot be negative")
+	}
 	if p.Limit == nil || *p.Limit > MAX_LIMIT {
 		defaultLimit := MAX_LIMIT
 		p.Limit = &defaultLimit
@@ -21,4 +136,5 @@ func (p *Pagination) Validate() {
 		defaultOffset := 0
 		p.Offset = &defaultOffset
 	}
+	return nil
 }
@@ -64,7 +64,9 @@ func TestPagination_Validate(t *testing.T) {
 				Limit:  tt.limit,
 				Offset: tt.offset,
 			}
-			p.Validate()
+			if err := p.Validate(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
 			if *p.Limit != *tt.expected.Limit {
 				t.Errorf("expected Limit %d, got %d", *tt.expected.Limit, *p.Limit)
 			}
@@ -75,6 +77,18 @@ func TestPagination_Validate(t *testing.T) {
 	}
 }
 
+func TestPagination_ValidateBounds(t *testing.T) {
+	for _, p := range []Pagination{
+		{Limit: intPtr(0)},
+		{Limit: intPtr(-1)},
+		{Offset: intPtr(-1)},
+	} {
+		if err := p.Validate(); err == nil {
+			t.Errorf("expected an error for limit %v and offset %v", p.Limit, p.Offset)
+		}
+	}
+}
+
 func intPtr(i int) *int {
 	return &i
 }
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -17,4 +18,54 @@ type Asset struct {
 	Social        *map[string]string `bun:"social" json:"social,omitempty"`
 	CreatedAt     *time.Time         `bun:"created_at" json:"created_at,omitempty"`
 	UpdatedAt     *time.Time         `bun:"updated_at" json:"updated_at,omitempty"`
+	// Version is incremented by every write, and is the ETag of the asset.
+	Version int `bun:"version,nullzero,notnull,default:1" json:"version,omitempty"`
+	// DeletedAt is set when the asset is deleted. Queries skip deleted assets unless asked otherwise.
+	DeletedAt *time.Time `bun:"deleted_at,soft_delete,nullzero" json:"deleted_at,omitempty"`
+	// Rank and Highlight are only set when searching.
+	Rank      *float64 `bun:"rank,scanonly" json:"rank,omitempty"`
+	Highlight *string  `bun:"highlight,scanonly" json:"highlight,omitempty"`
+}
+
+// Project returns the asset with only the fields, and the search results. Without fields, it returns the asset.
+func (a *Asset) Project(fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return a, nil
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	projection := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			projection[field] = value
+		}
+	}
+	for _, field := range []string{"rank", "highlight"} {
+		if value, ok := all[field]; ok {
+			projection[field] = value
+		}
+	}
+	return projection, nil
+}
+
+// ProjectAssets returns the assets with only the fields.
+func ProjectAssets(assets []*Asset, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return assets, nil
+	}
+	projections := make([]interface{}, len(assets))
+	for i, asset := range assets {
+		projection, err := asset.Project(fields)
+		if err != nil {
+			return nil, err
+		}
+		projections[i] = projection
+	}
+	return projections, nil
 }
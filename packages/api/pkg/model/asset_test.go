@@ -0,0 +1,48 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsset_Project(t *testing.T) {
+	blockchain := model.POLKADOT
+	image := "https://example.com/image.png"
+	description := "description"
+	asset := &model.Asset{ID: "asset1", Address: "owner", Blockchain: &blockchain, Image: &image, Description: &description}
+
+	projection, err := asset.Project([]string{"id", "image", "blockchain"})
+	require.NoError(t, err)
+	data, err := json.Marshal(projection)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"asset1","image":"https://example.com/image.png","blockchain":"polkadot"}`, string(data))
+
+	projection, err = asset.Project(nil)
+	require.NoError(t, err)
+	assert.Same(t, asset, projection)
+}
+
+func TestAsset_ProjectSearchResults(t *testing.T) {
+	rank := 0.5
+	asset := &model.Asset{ID: "asset1", Address: "owner", Rank: &rank}
+
+	projection, err := asset.Project([]string{"id", "image"})
+	require.NoError(t, err)
+	data, err := json.Marshal(projection)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"asset1","rank":0.5}`, string(data))
+}
+
+func TestProjectAssets(t *testing.T) {
+	assets := []*model.Asset{{ID: "asset1", Address: "owner"}, {ID: "asset2", Address: "owner"}}
+
+	projections, err := model.ProjectAssets(assets, []string{"id"})
+	require.NoError(t, err)
+	data, err := json.Marshal(projections)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id":"asset1"},{"id":"asset2"}]`, string(data))
+}
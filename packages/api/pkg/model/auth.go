@@ -1,6 +1,70 @@
 package model
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+)
+
 type Auth struct {
 	OK      bool   `json:"ok"`
 	Message string `json:"message"`
+	KeyType string `json:"key_type,omitempty"`
+}
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Address     string   `json:"address"`
+	Network     string   `json:"network,omitempty"`
+	KeyType     string   `json:"key_type,omitempty"`
+	AuthMethod  string   `json:"auth_method"`
+	SessionID   string   `json:"session_id,omitempty"`
+	APIKeyID    string   `json:"api_key_id,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	// Nonce is the signed nonce of the request, recorded in the revisions of the assets it changes.
+	Nonce string `json:"-"`
+	// Digest is the digest of the request body that the signed nonce was bound to, if any.
+	Digest string `json:"-"`
+	// SS58Prefix is the prefix of the address the caller signed with, if known.
+	SS58Prefix *uint16 `json:"-"`
+}
+
+// NewAddressPrincipal returns the principal of a caller that controls the address. Its address is the
+// canonical one, and its network and SS58 prefix those the address is encoded for.
+func NewAddressPrincipal(address, authMethod string, networks Networks) (*Principal, error) {
+	prefix, accountID, err := DecodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	return &Principal{
+		Address:    EncodeAddress(SS58_CANONICAL_PREFIX, accountID),
+		Network:    networks.prefixNetwork(prefix),
+		AuthMethod: authMethod,
+		SS58Prefix: &prefix,
+	}, nil
+}
+
+// CheckNetwork returns an error if the address the principal signed with is encoded for another
+// network. Principals whose prefix is unknown, like API keys, are not checked.
+func (p *Principal) CheckNetwork(network *Network) error {
+	if p.SS58Prefix == nil || network == nil || *p.SS58Prefix == network.SS58Prefix {
+		return nil
+	}
+	return fmt.Errorf("the address is encoded for the SS58 prefix %d, but %s addresses use %d", *p.SS58Prefix, network.DisplayName, network.SS58Prefix)
+}
+
+// Allows returns true if the principal may use the permission.
+// Only API keys are scoped, wallet principals are allowed everything.
+func (p *Principal) Allows(permission string) bool {
+	if p.AuthMethod != AUTH_METHOD_API_KEY {
+		return true
+	}
+	return slices.Contains(p.Permissions, permission)
+}
+
+// HashSecret returns the hash under which a secret token is stored.
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxBatchSize is the maximum number of assets of a batch request.
+const MaxBatchSize = 100
+
+type BatchGetAssets struct {
+	IDs []string `json:"ids"`
+}
+
+type BatchGetAssetsInput struct {
+	BatchGetAssets `in:"body=json;nonzero"`
+	Fields
+	// Projection is the parsed fields, set by Validate.
+	Projection []string
+}
+
+// Validate checks the ids and removes the repeated ones.
+func (c *BatchGetAssetsInput) Validate() error {
+	if len(c.IDs) == 0 {
+		return errors.New("ids are required")
+	}
+	if len(c.IDs) > MaxBatchSize {
+		return fmt.Errorf("ids accept at most %d values", MaxBatchSize)
+	}
+	var ids []string
+	seen := map[string]bool{}
+	for _, id := range c.IDs {
+		if err := validateID(id); err != nil {
+			return err
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	c.IDs = ids
+	projection, err := c.Fields.Parse(ASSET_COLUMNS)
+	if err != nil {
+		return err
+	}
+	c.Projection = projection
+	return nil
+}
+
+// AssetBatch is the result of a batch request: the assets found by id, and the ids not found.
+type AssetBatch struct {
+	Assets  map[string]interface{} `json:"assets"`
+	Missing []string               `json:"missing"`
+}
+
+// NewAssetBatch returns the batch of the assets found for the ids, with only the fields.
+func NewAssetBatch(ids []string, assets []*Asset, fields []string) (*AssetBatch, error) {
+	batch := &AssetBatch{Assets: make(map[string]interface{}, len(assets)), Missing: []string{}}
+	for _, asset := range assets {
+		projection, err := asset.Project(fields)
+		if err != nil {
+			return nil, err
+		}
+		batch.Assets[asset.ID] = projection
+	}
+	for _, id := range ids {
+		if _, ok := batch.Assets[id]; !ok {
+			batch.Missing = append(batch.Missing, id)
+		}
+	}
+	return batch, nil
+}
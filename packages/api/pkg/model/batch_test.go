@@ -0,0 +1,58 @@
+package model_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchGetAssetsInput_Validate(t *testing.T) {
+	tooMany := make([]string, model.MaxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("a%d", i+1)
+	}
+	tests := []struct {
+		name    string
+		ids     []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "ids", ids: []string{"abc", "def"}, want: []string{"abc", "def"}},
+		{name: "repeated ids", ids: []string{"abc", "def", "abc"}, want: []string{"abc", "def"}},
+		{name: "no ids", ids: nil, wantErr: true},
+		{name: "invalid id", ids: []string{"abc", "0OIl"}, wantErr: true},
+		{name: "too many ids", ids: tooMany, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := model.BatchGetAssetsInput{BatchGetAssets: model.BatchGetAssets{IDs: tt.ids}}
+			err := input.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, input.IDs)
+		})
+	}
+}
+
+func TestNewAssetBatch(t *testing.T) {
+	assets := []*model.Asset{{ID: "abc", Address: "owner"}}
+
+	batch, err := model.NewAssetBatch([]string{"abc", "def"}, assets, []string{"id"})
+	require.NoError(t, err)
+	data, err := json.Marshal(batch)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"assets":{"abc":{"id":"abc"}},"missing":["def"]}`, string(data))
+
+	batch, err = model.NewAssetBatch([]string{"def"}, nil, nil)
+	require.NoError(t, err)
+	data, err = json.Marshal(batch)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"assets":{},"missing":["def"]}`, string(data))
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// Operations of the items of a bulk request
+const BULK_CREATE = "create"
+const BULK_UPDATE = "update"
+const BULK_DELETE = "delete"
+
+// Modes of a bulk request. Atomic requests apply all their items or none.
+const BULK_MODE_ATOMIC = "atomic"
+const BULK_MODE_BEST_EFFORT = "best_effort"
+
+var digestPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// BulkItem is an operation on an asset. Deletes only have an id.
+type BulkItem struct {
+	Op          string             `json:"op"`
+	ID          string             `json:"id"`
+	Blockchain  *string            `json:"blockchain"`
+	Description *string            `json:"description"`
+	Image       *string            `json:"image"`
+	Social      *map[string]string `json:"social"`
+}
+
+// Permission returns the API key permission required by the operation.
+func (i *BulkItem) Permission() string {
+	switch i.Op {
+	case BULK_CREATE:
+		return PERMISSION_CREATE
+	case BULK_UPDATE:
+		return PERMISSION_UPDATE
+	default:
+		return PERMISSION_DELETE
+	}
+}
+
+// Asset returns the asset of the item, owned by the address when it's created.
+func (i *BulkItem) Asset(address string) *Asset {
+	asset := &Asset{
+		ID:          i.ID,
+		Blockchain:  i.Blockchain,
+		Description: i.Description,
+		Image:       i.Image,
+		Social:      i.Social,
+	}
+	if i.Op == BULK_CREATE {
+		asset.Address = address
+	}
+	return asset
+}
+
+func (i *BulkItem) Validate() error {
+	switch i.Op {
+	case BULK_CREATE:
+		create := CreateAssetInput{NewAsset: NewAsset{ID: i.ID, Description: i.Description, Image: i.Image, Social: i.Social}}
+		if i.Blockchain != nil {
+			create.Blockchain = *i.Blockchain
+		}
+		return create.Validate()
+	case BULK_UPDATE:
+		update := UpdateAssetInput{ID: i.ID, UpdateAsset: UpdateAsset{Blockchain: i.Blockchain, Description: i.Description, Image: i.Image, Social: i.Social}}
+		return update.Validate()
+	case BULK_DELETE:
+		if i.Blockchain != nil || i.Description != nil || i.Image != nil || i.Social != nil {
+			return errors.New("delete only accepts an id")
+		}
+		return validateID(i.ID)
+	}
+	return fmt.Errorf("op must be one of: %s, %s, %s", BULK_CREATE, BULK_UPDATE, BULK_DELETE)
+}
+
+type BulkAssets struct {
+	Mode  *string    `json:"mode"`
+	Items []BulkItem `json:"items"`
+}
+
+type BulkAssetsInput struct {
+	BulkAssets `in:"body=json;nonzero"`
+}
+
+// IsAtomic returns whether the items are applied in a single transaction, the default.
+func (c *BulkAssets) IsAtomic() bool {
+	return c.Mode == nil || *c.Mode == BULK_MODE_ATOMIC
+}
+
+func (c *BulkAssetsInput) Validate() error {
+	if c.Mode != nil && *c.Mode != BULK_MODE_ATOMIC && *c.Mode != BULK_MODE_BEST_EFFORT {
+		return fmt.Errorf("mode must be '%s' or '%s'", BULK_MODE_ATOMIC, BULK_MODE_BEST_EFFORT)
+	}
+	if len(c.Items) == 0 {
+		return errors.New("items are required")
+	}
+	if len(c.Items) > MaxBatchSize {
+		return fmt.Errorf("items accept at most %d values", MaxBatchSize)
+	}
+	for i := range c.Items {
+		if err := c.Items[i].Validate(); err != nil {
+			return fmt.Errorf("items[%d]: %s", i, err)
+		}
+	}
+	return nil
+}
+
+// BulkResult is the result of an item of a bulk request. Err is set by the app, and the
+// status and error by the service.
+type BulkResult struct {
+	Index  int    `json:"index"`
+	Op     string `json:"op"`
+	ID     string `json:"id"`
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Asset  *Asset `json:"asset,omitempty"`
+	Err    error  `json:"-"`
+}
+
+// BulkResponse is the response of a bulk request.
+type BulkResponse struct {
+	Mode      string        `json:"mode"`
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Results   []*BulkResult `json:"results"`
+}
+
+func validateDigest(digest string) error {
+	if !digestPattern.MatchString(digest) {
+		return errors.New("must be the lowercase hex SHA-256 of the request body")
+	}
+	return nil
+}
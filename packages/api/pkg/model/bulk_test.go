@@ -0,0 +1,54 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkAssetsInput_Validate(t *testing.T) {
+	polkadot := model.POLKADOT
+	image := "https://example.com/image.png"
+	bestEffort := model.BULK_MODE_BEST_EFFORT
+	tests := []struct {
+		name    string
+		input   model.BulkAssets
+		wantErr bool
+	}{
+		{name: "items", input: model.BulkAssets{Items: []model.BulkItem{
+			{Op: model.BULK_CREATE, ID: "abc", Blockchain: &polkadot, Image: &image},
+			{Op: model.BULK_UPDATE, ID: "abc", Image: &image},
+			{Op: model.BULK_DELETE, ID: "def"},
+		}}},
+		{name: "best effort", input: model.BulkAssets{Mode: &bestEffort, Items: []model.BulkItem{{Op: model.BULK_DELETE, ID: "def"}}}},
+		{name: "unknown mode", input: model.BulkAssets{Mode: strPtr("eventual"), Items: []model.BulkItem{{Op: model.BULK_DELETE, ID: "def"}}}, wantErr: true},
+		{name: "no items", input: model.BulkAssets{}, wantErr: true},
+		{name: "too many items", input: model.BulkAssets{Items: make([]model.BulkItem, model.MaxBatchSize+1)}, wantErr: true},
+		{name: "unknown op", input: model.BulkAssets{Items: []model.BulkItem{{Op: "upsert", ID: "abc"}}}, wantErr: true},
+		{name: "create without blockchain", input: model.BulkAssets{Items: []model.BulkItem{{Op: model.BULK_CREATE, ID: "abc"}}}, wantErr: true},
+		{name: "invalid update", input: model.BulkAssets{Items: []model.BulkItem{{Op: model.BULK_UPDATE, ID: "abc", Image: strPtr("not a url")}}}, wantErr: true},
+		{name: "delete with fields", input: model.BulkAssets{Items: []model.BulkItem{{Op: model.BULK_DELETE, ID: "abc", Image: &image}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := model.BulkAssetsInput{BulkAssets: tt.input}
+			err := input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BulkAssetsInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBulkItem(t *testing.T) {
+	create := model.BulkItem{Op: model.BULK_CREATE, ID: "abc"}
+	update := model.BulkItem{Op: model.BULK_UPDATE, ID: "abc"}
+
+	assert.Equal(t, model.PERMISSION_CREATE, create.Permission())
+	assert.Equal(t, model.PERMISSION_UPDATE, update.Permission())
+	assert.Equal(t, model.PERMISSION_DELETE, (&model.BulkItem{Op: model.BULK_DELETE}).Permission())
+	assert.Equal(t, "owner", create.Asset("owner").Address)
+	assert.Empty(t, update.Asset("owner").Address)
+	assert.True(t, (&model.BulkAssets{}).IsAtomic())
+}
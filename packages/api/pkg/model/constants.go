@@ -2,3 +2,93 @@ package model
 
 const POLKADOT = "polkadot"
 const KUSAMA = "kusama"
+const SUBSTRATE = "substrate"
+
+// Networks by SS58 address prefix, for the prefixes that are not in the registry of the networks
+var SS58_NETWORKS = map[uint16]string{
+	0:  POLKADOT,
+	2:  KUSAMA,
+	42: SUBSTRATE,
+}
+
+// Columns of the fields of assets that can be selected, by their name in the fields argument.
+var ASSET_COLUMNS = map[string]string{
+	"_id":         "_id",
+	"id":          "id",
+	"address":     "address",
+	"blockchain":  "blockchain",
+	"description": "description",
+	"image":       "image",
+	"social":      "social",
+	"created_at":  "created_at",
+	"updated_at":  "updated_at",
+	"version":     "version",
+	"deleted_at":  "deleted_at",
+}
+
+// Columns that assets can be ordered by, by their name in the order argument.
+// _id is the insertion order, and it's the last key of every order to make it unique.
+var ASSET_ORDER_COLUMNS = map[string]string{
+	"_id":        "_id",
+	"id":         "id",
+	"address":    "address",
+	"blockchain": "blockchain",
+	"created_at": "created_at",
+}
+
+// Nonce formats
+const FORMAT_SIWS = "siws"
+
+// Signature key types
+const KEY_TYPE_SR25519 = "sr25519"
+const KEY_TYPE_ED25519 = "ed25519"
+const KEY_TYPE_ECDSA = "ecdsa"
+
+// Authentication methods of a principal
+const AUTH_METHOD_SIGNATURE = "signature"
+const AUTH_METHOD_SESSION = "session"
+const AUTH_METHOD_DEV = "dev"
+const AUTH_METHOD_API_KEY = "api_key"
+
+// Permissions of an API key
+const PERMISSION_CREATE = "create"
+const PERMISSION_UPDATE = "update"
+const PERMISSION_DELETE = "delete"
+const PERMISSION_RESTORE = "restore"
+const PERMISSION_UPLOAD = "upload"
+
+// Actions that a nonce can be requested for
+const ACTION_CREATE_ASSET = "create_asset"
+const ACTION_UPDATE_ASSET = "update_asset"
+const ACTION_DELETE_ASSET = "delete_asset"
+const ACTION_PATCH_ASSET = "patch_asset"
+const ACTION_CREATE_SESSION = "create_session"
+const ACTION_SET_DELEGATE = "set_delegate"
+const ACTION_DELETE_DELEGATE = "delete_delegate"
+const ACTION_CREATE_API_KEY = "create_api_key"
+const ACTION_LIST_API_KEYS = "list_api_keys"
+const ACTION_REVOKE_API_KEY = "revoke_api_key"
+const ACTION_BULK_ASSETS = "bulk_assets"
+const ACTION_RESTORE_ASSET = "restore_asset"
+const ACTION_ADMIN_LIST_ASSETS = "admin_list_assets"
+const ACTION_OFFER_TRANSFER = "offer_transfer"
+const ACTION_ACCEPT_TRANSFER = "accept_transfer"
+const ACTION_CANCEL_TRANSFER = "cancel_transfer"
+
+// Operations recorded in the revisions of an asset
+const REVISION_CREATE = "create"
+const REVISION_UPDATE = "update"
+const REVISION_DELETE = "delete"
+const REVISION_RESTORE = "restore"
+const REVISION_TRANSFER = "transfer"
+
+// Statuses of an ownership transfer
+const TRANSFER_PENDING = "pending"
+const TRANSFER_ACCEPTED = "accepted"
+const TRANSFER_CANCELLED = "cancelled"
+const TRANSFER_EXPIRED = "expired"
+
+// Roles of a delegate
+const ROLE_CO_OWNER = "co_owner"
+const ROLE_PROXY = "proxy"
+const ROLE_MEMBER = "member"
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Cursor is the position after the last asset of a page, for keyset pagination.
+// It holds the values of the asset for each key of the order.
+type Cursor struct {
+	Order  string   `json:"o"`
+	Values []string `json:"v"`
+}
+
+// NewCursor returns the cursor after the asset, or nil if the asset cannot be used as a cursor.
+func NewCursor(asset *Asset, keys []OrderKey) *Cursor {
+	if len(keys) == 0 {
+		return nil
+	}
+	cursor := &Cursor{Order: OrderString(keys)}
+	for _, key := range keys {
+		value, ok := asset.orderValue(key.Field)
+		if !ok {
+			return nil
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+	return cursor
+}
+
+// orderValue returns the value of an order field of the asset, if it's set.
+func (a *Asset) orderValue(field string) (string, bool) {
+	switch field {
+	case "_id":
+		if a.ID_ != nil {
+			return strconv.Itoa(*a.ID_), true
+		}
+	case "id":
+		return a.ID, true
+	case "address":
+		return a.Address, true
+	case "blockchain":
+		if a.Blockchain != nil {
+			return *a.Blockchain, true
+		}
+	case "created_at":
+		if a.CreatedAt != nil {
+			return a.CreatedAt.Format(time.RFC3339Nano), true
+		}
+	}
+	return "", false
+}
+
+// Encode returns the opaque representation of the cursor sent to clients.
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned by Encode.
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("cursor is invalid")
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, errors.New("cursor is invalid")
+	}
+	return &cursor, nil
+}
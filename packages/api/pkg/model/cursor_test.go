@@ -0,0 +1,41 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	internalID := 42
+	createdAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	asset := &model.Asset{ID_: &internalID, ID: "asset42", CreatedAt: &createdAt}
+	keys := []model.OrderKey{{Field: "created_at", Ascending: false}, {Field: "id", Ascending: true}, {Field: "_id", Ascending: false}}
+
+	cursor := model.NewCursor(asset, keys)
+	require.NotNil(t, cursor)
+
+	decoded, err := model.DecodeCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+	assert.Equal(t, "created_at:desc,id:asc,_id:desc", decoded.Order)
+	assert.Equal(t, []string{createdAt.Format(time.RFC3339Nano), "asset42", "42"}, decoded.Values)
+}
+
+func TestNewCursor_Unusable(t *testing.T) {
+	internalID := 1
+	assert.Nil(t, model.NewCursor(&model.Asset{ID_: &internalID}, nil))
+	assert.Nil(t, model.NewCursor(&model.Asset{ID: "asset1"}, []model.OrderKey{{Field: "_id"}}))
+	assert.Nil(t, model.NewCursor(&model.Asset{ID_: &internalID}, []model.OrderKey{{Field: "created_at"}, {Field: "_id"}}))
+	assert.Nil(t, model.NewCursor(&model.Asset{ID_: &internalID}, []model.OrderKey{{Field: "unknown"}}))
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	_, err := model.DecodeCursor("not a cursor!")
+	assert.Error(t, err)
+	_, err = model.DecodeCursor("bm90IGpzb24")
+	assert.Error(t, err)
+}
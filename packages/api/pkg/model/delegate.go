@@ -0,0 +1,45 @@
+package model
+
+import (
+	"sort"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Delegate is an address allowed to act on the assets of an owner, such as a proxy or multisig member.
+type Delegate struct {
+	bun.BaseModel `bun:"table:delegates,alias:d"`
+	ID            *int      `bun:"id" json:"id"`
+	Owner         string    `bun:"owner,notnull" json:"owner"`
+	Address       string    `bun:"address,notnull" json:"address"`
+	Role          string    `bun:"role,notnull" json:"role"`
+	CreatedAt     time.Time `bun:"created_at" json:"created_at"`
+}
+
+// Can returns true if the role of the delegate allows the action on the assets of the owner.
+func (d *Delegate) Can(action string) bool {
+	for _, allowed := range rolePermissions[d.Role] {
+		if allowed == action {
+			return true
+		}
+	}
+	return false
+}
+
+// rolePermissions maps the roles of a delegate to the actions they allow.
+var rolePermissions = map[string][]string{
+	ROLE_CO_OWNER: {ACTION_UPDATE_ASSET, ACTION_DELETE_ASSET},
+	ROLE_PROXY:    {ACTION_UPDATE_ASSET, ACTION_DELETE_ASSET},
+	ROLE_MEMBER:   {ACTION_UPDATE_ASSET},
+}
+
+// roles returns the sorted list of delegate roles.
+func roles() []string {
+	names := make([]string, 0, len(rolePermissions))
+	for name := range rolePermissions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ETag returns the entity tag of the version of an asset.
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// MatchesETag returns true if the If-None-Match header matches the version: it is "*", or one
+// of its entity tags is the one of the version. Weak tags match their strong counterparts.
+func MatchesETag(header string, version int) bool {
+	etag := ETag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// Precondition is the If-Match header of a write, the version of the asset it expects.
+type Precondition struct {
+	IfMatch *string `in:"header=If-Match"`
+	// Version is the parsed If-Match, set by Validate. It's nil if any version matches.
+	Version *int
+}
+
+// Validate parses If-Match. It must be "*" or the strong entity tag of a version.
+func (p *Precondition) Validate() error {
+	if p.IfMatch == nil {
+		return nil
+	}
+	tag := strings.TrimSpace(*p.IfMatch)
+	if tag == "*" {
+		return nil
+	}
+	if strings.HasPrefix(tag, "W/") {
+		return errors.New("If-Match cannot be a weak entity tag")
+	}
+	value, err := strconv.Unquote(tag)
+	if err != nil || !strings.HasPrefix(tag, `"`) {
+		return fmt.Errorf("If-Match must be \"*\" or a single entity tag, such as %s", ETag(1))
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return fmt.Errorf("If-Match must be \"*\" or a single entity tag, such as %s", ETag(1))
+	}
+	p.Version = &version
+	return nil
+}
@@ -0,0 +1,40 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesETag(t *testing.T) {
+	assert.Equal(t, `"3"`, model.ETag(3))
+	assert.True(t, model.MatchesETag(`"3"`, 3))
+	assert.True(t, model.MatchesETag(`"1", W/"3"`, 3))
+	assert.True(t, model.MatchesETag("*", 3))
+	assert.False(t, model.MatchesETag(`"2"`, 3))
+	assert.False(t, model.MatchesETag(`3`, 3))
+}
+
+func TestPrecondition_Validate(t *testing.T) {
+	header := func(value string) model.Precondition {
+		return model.Precondition{IfMatch: &value}
+	}
+
+	precondition := header(`"3"`)
+	require.NoError(t, precondition.Validate())
+	assert.Equal(t, 3, *precondition.Version)
+
+	precondition = header("*")
+	require.NoError(t, precondition.Validate())
+	assert.Nil(t, precondition.Version)
+	precondition = model.Precondition{}
+	require.NoError(t, precondition.Validate())
+	assert.Nil(t, precondition.Version)
+
+	for _, value := range []string{`W/"3"`, `"1", "2"`, `3`, `"0"`, `"abc"`} {
+		precondition = header(value)
+		assert.Error(t, precondition.Validate(), value)
+	}
+}
@@ -0,0 +1,174 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Placeholder of the address in the explorer URL of a network
+const placeholderAddress = "{address}"
+
+var networkNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Network is a blockchain that assets can be registered on, and the SS58 prefix its addresses are
+// encoded with. Disabled networks are still known, so their assets can be listed, but no new assets
+// can be registered on them.
+type Network struct {
+	Name        string `json:"name"`
+	SS58Prefix  uint16 `json:"ss58_prefix"`
+	DisplayName string `json:"display_name"`
+	ExplorerURL string `json:"explorer_url,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// Explorer returns the URL of the address in the explorer of the network, or "" if it has none.
+func (n *Network) Explorer(address string) string {
+	if n.ExplorerURL == "" {
+		return ""
+	}
+	return strings.ReplaceAll(n.ExplorerURL, placeholderAddress, address)
+}
+
+func (n *Network) Validate() error {
+	if !networkNamePattern.MatchString(n.Name) {
+		return fmt.Errorf("network name '%s' must be lowercase alphanumeric or '-'", n.Name)
+	}
+	if n.SS58Prefix > maxSS58Prefix {
+		return fmt.Errorf("network '%s': ss58_prefix must be at most %d", n.Name, maxSS58Prefix)
+	}
+	if n.DisplayName == "" {
+		return fmt.Errorf("network '%s': display_name is required", n.Name)
+	}
+	if n.ExplorerURL != "" && !strings.Contains(n.ExplorerURL, placeholderAddress) {
+		return fmt.Errorf("network '%s': explorer_url must contain '%s'", n.Name, placeholderAddress)
+	}
+	return nil
+}
+
+// Networks is the registry of the networks that assets can be registered on, in order. It's loaded at
+// startup and not changed afterwards.
+type Networks []Network
+
+// DefaultNetworks returns the networks when none are configured.
+func DefaultNetworks() Networks {
+	return Networks{
+		{Name: POLKADOT, SS58Prefix: 0, DisplayName: "Polkadot", ExplorerURL: "https://polkadot.subscan.io/account/{address}", Enabled: true},
+		{Name: KUSAMA, SS58Prefix: 2, DisplayName: "Kusama", ExplorerURL: "https://kusama.subscan.io/account/{address}", Enabled: true},
+	}
+}
+
+// NewNetworks returns the registry of the networks, in the order they are listed.
+func NewNetworks(list []Network) (Networks, error) {
+	if len(list) == 0 {
+		return nil, errors.New("at least one network is required")
+	}
+	names := map[string]bool{}
+	for i := range list {
+		if err := list[i].Validate(); err != nil {
+			return nil, err
+		}
+		if names[list[i].Name] {
+			return nil, fmt.Errorf("network '%s' is duplicated", list[i].Name)
+		}
+		names[list[i].Name] = true
+	}
+	return append(Networks{}, list...), nil
+}
+
+// LoadNetworks returns the registry of the networks of a JSON file with an array of networks.
+func LoadNetworks(path string) (Networks, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var list []Network
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("error parsing the networks of '%s': %w", path, err)
+	}
+	return NewNetworks(list)
+}
+
+// Get returns the network with the name, or nil if it's not in the registry.
+func (n Networks) Get(name string) *Network {
+	for i := range n {
+		if n[i].Name == name {
+			network := n[i]
+			return &network
+		}
+	}
+	return nil
+}
+
+// ByPrefix returns the first network of the registry with the SS58 prefix, or nil if there is none.
+// Networks can share a prefix, like a relay chain and its system parachains.
+func (n Networks) ByPrefix(prefix uint16) *Network {
+	for i := range n {
+		if n[i].SS58Prefix == prefix {
+			network := n[i]
+			return &network
+		}
+	}
+	return nil
+}
+
+// Enabled returns the names of the networks that assets can be registered on.
+func (n Networks) Enabled() []string {
+	var names []string
+	for _, network := range n {
+		if network.Enabled {
+			names = append(names, network.Name)
+		}
+	}
+	return names
+}
+
+// ValidateBlockchain checks that assets can be registered on the blockchain: it's an enabled network.
+func (n Networks) ValidateBlockchain(blockchain string) error {
+	if network := n.Get(blockchain); network == nil || !network.Enabled {
+		return fmt.Errorf("blockchain must be one of: %s", strings.Join(n.Enabled(), ", "))
+	}
+	return nil
+}
+
+// ValidateBlockchainFilter checks that the blockchain is a network of the registry, enabled or not.
+func (n Networks) ValidateBlockchainFilter(blockchain string) error {
+	if n.Get(blockchain) == nil {
+		return fmt.Errorf("blockchain '%s' is not a known network", blockchain)
+	}
+	return nil
+}
+
+// AddressNetwork returns the name of the network the address is encoded for, if known: the first one
+// of the registry with its prefix, or else the generic one of its prefix.
+func (n Networks) AddressNetwork(address string) string {
+	prefix, _, err := DecodeAddress(address)
+	if err != nil {
+		return ""
+	}
+	return n.prefixNetwork(prefix)
+}
+
+func (n Networks) prefixNetwork(prefix uint16) string {
+	if network := n.ByPrefix(prefix); network != nil {
+		return network.Name
+	}
+	return SS58_NETWORKS[prefix]
+}
+
+// Prefix returns the SS58 prefix of the network with the name, from the registry or else the generic
+// networks, or nil if it's unknown.
+func (n Networks) Prefix(name string) *uint16 {
+	if network := n.Get(name); network != nil {
+		return &network.SS58Prefix
+	}
+	for prefix, network := range SS58_NETWORKS {
+		if network == name {
+			return &prefix
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package model_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworks_Default(t *testing.T) {
+	networks := model.DefaultNetworks()
+	require.Len(t, networks, 2)
+	assert.Equal(t, model.POLKADOT, networks[0].Name)
+	assert.Equal(t, model.KUSAMA, networks[1].Name)
+	assert.Equal(t, uint16(2), networks.Get(model.KUSAMA).SS58Prefix)
+	assert.Equal(t, model.POLKADOT, networks.ByPrefix(0).Name)
+	assert.Nil(t, networks.Get("westend"))
+	assert.Nil(t, networks.ByPrefix(42))
+	assert.Equal(t, "https://polkadot.subscan.io/account/1abc", networks.Get(model.POLKADOT).Explorer("1abc"))
+
+	// Each call returns its own registry.
+	networks[0].Enabled = false
+	assert.True(t, model.DefaultNetworks()[0].Enabled)
+}
+
+func TestNewNetworks(t *testing.T) {
+	networks, err := model.NewNetworks([]model.Network{
+		{Name: model.POLKADOT, SS58Prefix: 0, DisplayName: "Polkadot", Enabled: true},
+		{Name: "asset-hub-polkadot", SS58Prefix: 0, DisplayName: "Asset Hub", Enabled: true},
+		{Name: "westend", SS58Prefix: 42, DisplayName: "Westend"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.POLKADOT, networks.ByPrefix(0).Name)
+	assert.Equal(t, "westend", networks.ByPrefix(42).Name)
+	assert.Nil(t, networks.Get(model.KUSAMA))
+	assert.Equal(t, "", networks.Get("westend").Explorer("5abc"))
+	assert.Equal(t, []string{model.POLKADOT, "asset-hub-polkadot"}, networks.Enabled())
+
+	for name, list := range map[string][]model.Network{
+		"empty":             {},
+		"duplicated":        {{Name: "westend", DisplayName: "Westend"}, {Name: "westend", DisplayName: "Westend"}},
+		"invalid name":      {{Name: "West End", DisplayName: "Westend"}},
+		"no display name":   {{Name: "westend"}},
+		"invalid prefix":    {{Name: "westend", DisplayName: "Westend", SS58Prefix: 16384}},
+		"no address in url": {{Name: "westend", DisplayName: "Westend", ExplorerURL: "https://westend.subscan.io"}},
+	} {
+		_, err := model.NewNetworks(list)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLoadNetworks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "networks.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"name": "westend", "ss58_prefix": 42, "display_name": "Westend", "explorer_url": "https://westend.subscan.io/account/{address}", "enabled": true}
+	]`), 0o600))
+	networks, err := model.LoadNetworks(path)
+	require.NoError(t, err)
+	network := networks.Get("westend")
+	require.NotNil(t, network)
+	assert.Equal(t, model.Network{Name: "westend", SS58Prefix: 42, DisplayName: "Westend", ExplorerURL: "https://westend.subscan.io/account/{address}", Enabled: true}, *network)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"name": "westend"}`), 0o600))
+	_, err = model.LoadNetworks(path)
+	assert.Error(t, err)
+	_, err = model.LoadNetworks(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestNetworks_ValidateBlockchain(t *testing.T) {
+	networks, err := model.NewNetworks([]model.Network{
+		{Name: model.POLKADOT, SS58Prefix: 0, DisplayName: "Polkadot", Enabled: true},
+		{Name: "westend", SS58Prefix: 42, DisplayName: "Westend", Enabled: true},
+		{Name: model.KUSAMA, SS58Prefix: 2, DisplayName: "Kusama"},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, networks.ValidateBlockchain("westend"))
+	assert.ErrorContains(t, networks.ValidateBlockchain(model.KUSAMA), "blockchain must be one of: polkadot, westend")
+	assert.Error(t, networks.ValidateBlockchain("rococo"))
+
+	// Assets on disabled networks can still be listed.
+	assert.NoError(t, networks.ValidateBlockchainFilter(model.KUSAMA))
+	assert.NoError(t, networks.ValidateBlockchainFilter("westend"))
+	assert.ErrorContains(t, networks.ValidateBlockchainFilter("rococo"), "blockchain 'rococo' is not a known network")
+}
+
+func TestNetworks_Prefix(t *testing.T) {
+	networks := model.DefaultNetworks()
+	assert.Equal(t, uint16(2), *networks.Prefix(model.KUSAMA))
+	assert.Equal(t, uint16(42), *networks.Prefix(model.SUBSTRATE))
+	assert.Nil(t, networks.Prefix("rococo"))
+}
@@ -0,0 +1,275 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Media types of the patches of an asset
+const MERGE_PATCH = "application/merge-patch+json"
+const JSON_PATCH = "application/json-patch+json"
+
+// Operations of a JSON Patch
+const PATCH_ADD = "add"
+const PATCH_REMOVE = "remove"
+const PATCH_REPLACE = "replace"
+const PATCH_MOVE = "move"
+const PATCH_COPY = "copy"
+const PATCH_TEST = "test"
+
+// patchableFields are the fields of an asset that a patch can change.
+var patchableFields = []string{"blockchain", "description", "image", "social"}
+
+type PatchAssetInput struct {
+	ID          string          `in:"path=id"`
+	ContentType string          `in:"header=Content-Type"`
+	Patch       json.RawMessage `in:"body=json"`
+	Precondition
+}
+
+// MediaType returns the format of the patch, MERGE_PATCH or JSON_PATCH, or "" if it's not supported.
+func (c *PatchAssetInput) MediaType() string {
+	mediaType, _, err := mime.ParseMediaType(c.ContentType)
+	if err != nil || (mediaType != MERGE_PATCH && mediaType != JSON_PATCH) {
+		return ""
+	}
+	return mediaType
+}
+
+func (c *PatchAssetInput) Validate() error {
+	if err := validateID(c.ID); err != nil {
+		return err
+	}
+	if err := c.Precondition.Validate(); err != nil {
+		return err
+	}
+	if len(c.Patch) == 0 {
+		return errors.New("patch is required")
+	}
+	return nil
+}
+
+// Patch returns the patchable fields of the asset after applying the patch, a JSON Merge Patch
+// (RFC 7396) or a JSON Patch (RFC 6902) by its media type. Removed fields are nil.
+func (a *Asset) Patch(mediaType string, patch json.RawMessage) (*UpdateAsset, error) {
+	document, err := a.patchDocument()
+	if err != nil {
+		return nil, err
+	}
+	switch mediaType {
+	case MERGE_PATCH:
+		document, err = mergePatch(document, patch)
+	case JSON_PATCH:
+		err = jsonPatch(document, patch)
+	default:
+		err = fmt.Errorf("patches must be '%s' or '%s'", MERGE_PATCH, JSON_PATCH)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchedFields(document)
+}
+
+// patchDocument returns the patchable fields of the asset as they are in its JSON, without the null ones.
+func (a *Asset) patchDocument() (map[string]interface{}, error) {
+	data, err := json.Marshal(&UpdateAsset{Blockchain: a.Blockchain, Description: a.Description, Image: a.Image, Social: a.Social})
+	if err != nil {
+		return nil, err
+	}
+	document := map[string]interface{}{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	for field, value := range document {
+		if value == nil {
+			delete(document, field)
+		}
+	}
+	return document, nil
+}
+
+// patchedFields reads and validates the fields of the patched document. Whether the blockchain can be
+// changed to a network depends on the registry, so the app checks it only if it changed.
+func patchedFields(document map[string]interface{}) (*UpdateAsset, error) {
+	for field := range document {
+		if !slices.Contains(patchableFields, field) {
+			return nil, fmt.Errorf("field '%s' cannot be patched, the fields are: %s", field, strings.Join(patchableFields, ", "))
+		}
+	}
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var fields UpdateAsset
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.New("blockchain, description and image must be strings, and social an object of strings")
+	}
+	if fields.Blockchain == nil {
+		return nil, errors.New("blockchain cannot be removed")
+	}
+	if err := validateImage(fields.Image); err != nil {
+		return nil, err
+	}
+	if err := validateDescription(fields.Description); err != nil {
+		return nil, err
+	}
+	if err := validateSocial(fields.Social); err != nil {
+		return nil, err
+	}
+	if err := validateBlockchain(fields.Blockchain); err != nil {
+		return nil, err
+	}
+	return &fields, nil
+}
+
+// mergePatch applies the JSON Merge Patch to the document: its members replace those of the
+// document, objects are merged recursively and nulls remove members.
+func mergePatch(document map[string]interface{}, patch json.RawMessage) (map[string]interface{}, error) {
+	var members map[string]interface{}
+	if err := json.Unmarshal(patch, &members); err != nil || members == nil {
+		return nil, errors.New("a merge patch must be a JSON object")
+	}
+	return mergeObjects(document, members), nil
+}
+
+func mergeObjects(target, patch map[string]interface{}) map[string]interface{} {
+	for name, value := range patch {
+		if value == nil {
+			delete(target, name)
+			continue
+		}
+		members, ok := value.(map[string]interface{})
+		if !ok {
+			target[name] = value
+			continue
+		}
+		object, ok := target[name].(map[string]interface{})
+		if !ok {
+			object = map[string]interface{}{}
+		}
+		target[name] = mergeObjects(object, members)
+	}
+	return target
+}
+
+// PatchOperation is an operation of a JSON Patch. Paths are JSON Pointers to members of objects,
+// as the patchable fields of an asset have no arrays.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// jsonPatch applies the operations of the JSON Patch to the document, in order. It fails at the first
+// operation that fails.
+func jsonPatch(document map[string]interface{}, patch json.RawMessage) error {
+	var operations []PatchOperation
+	if err := json.Unmarshal(patch, &operations); err != nil || operations == nil {
+		return errors.New("a JSON Patch must be an array of operations")
+	}
+	for i, operation := range operations {
+		if err := operation.apply(document); err != nil {
+			return fmt.Errorf("operation %d (%s %s): %s", i, operation.Op, operation.Path, err)
+		}
+	}
+	return nil
+}
+
+func (o *PatchOperation) apply(document map[string]interface{}) error {
+	parent, name, err := resolvePointer(document, o.Path)
+	if err != nil {
+		return err
+	}
+	current, exists := parent[name]
+	switch o.Op {
+	case PATCH_ADD, PATCH_REPLACE, PATCH_TEST:
+		value, err := o.value()
+		if err != nil {
+			return err
+		}
+		if o.Op != PATCH_ADD && !exists {
+			return errors.New("the path does not exist")
+		}
+		if o.Op == PATCH_TEST {
+			if !reflect.DeepEqual(current, value) {
+				return errors.New("the value does not match")
+			}
+			return nil
+		}
+		parent[name] = value
+	case PATCH_REMOVE:
+		if !exists {
+			return errors.New("the path does not exist")
+		}
+		delete(parent, name)
+	case PATCH_MOVE, PATCH_COPY:
+		if o.Op == PATCH_MOVE && strings.HasPrefix(o.Path, o.From+"/") {
+			return errors.New("a value cannot be moved into itself")
+		}
+		fromParent, fromName, err := resolvePointer(document, o.From)
+		if err != nil {
+			return fmt.Errorf("from: %s", err)
+		}
+		value, ok := fromParent[fromName]
+		if !ok {
+			return errors.New("from: the path does not exist")
+		}
+		if o.Op == PATCH_MOVE {
+			delete(fromParent, fromName)
+		} else if value, err = deepCopy(value); err != nil {
+			return err
+		}
+		parent[name] = value
+	default:
+		return fmt.Errorf("op must be one of: %s", strings.Join([]string{PATCH_ADD, PATCH_REMOVE, PATCH_REPLACE, PATCH_MOVE, PATCH_COPY, PATCH_TEST}, ", "))
+	}
+	return nil
+}
+
+func (o *PatchOperation) value() (interface{}, error) {
+	if len(o.Value) == 0 {
+		return nil, errors.New("value is required")
+	}
+	var value interface{}
+	if err := json.Unmarshal(o.Value, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// resolvePointer returns the object that holds the member the JSON Pointer refers to, and its name.
+// The member itself may not exist.
+func resolvePointer(document map[string]interface{}, pointer string) (map[string]interface{}, string, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, "", fmt.Errorf("path '%s' must point to a field, such as /description", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	parent := document
+	for _, token := range tokens[:len(tokens)-1] {
+		child, ok := parent[token].(map[string]interface{})
+		if !ok {
+			return nil, "", fmt.Errorf("path '%s' does not exist or is not an object", pointer)
+		}
+		parent = child
+	}
+	return parent, tokens[len(tokens)-1], nil
+}
+
+func deepCopy(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var copied interface{}
+	err = json.Unmarshal(data, &copied)
+	return copied, err
+}
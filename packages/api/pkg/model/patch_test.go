@@ -0,0 +1,91 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func patchableAsset() *model.Asset {
+	blockchain := model.POLKADOT
+	description := "description"
+	image := "https://example.com/image.png"
+	social := map[string]string{"twitter": "https://x.com/asset", "github": "https://github.com/asset"}
+	return &model.Asset{ID: "asset1", Address: "owner", Blockchain: &blockchain, Description: &description, Image: &image, Social: &social}
+}
+
+func TestAsset_PatchMerge(t *testing.T) {
+	fields, err := patchableAsset().Patch(model.MERGE_PATCH, json.RawMessage(`{
+		"description": null,
+		"social": {"twitter": null, "discord": "https://discord.gg/asset"}
+	}`))
+
+	require.NoError(t, err)
+	assert.Nil(t, fields.Description)
+	assert.Equal(t, "https://example.com/image.png", *fields.Image)
+	assert.Equal(t, map[string]string{"github": "https://github.com/asset", "discord": "https://discord.gg/asset"}, *fields.Social)
+
+	fields, err = patchableAsset().Patch(model.MERGE_PATCH, json.RawMessage(`{"social": null, "image": null}`))
+	require.NoError(t, err)
+	assert.Nil(t, fields.Social)
+	assert.Nil(t, fields.Image)
+	assert.Equal(t, model.POLKADOT, *fields.Blockchain)
+}
+
+func TestAsset_PatchJSON(t *testing.T) {
+	fields, err := patchableAsset().Patch(model.JSON_PATCH, json.RawMessage(`[
+		{"op": "test", "path": "/description", "value": "description"},
+		{"op": "remove", "path": "/description"},
+		{"op": "add", "path": "/social/discord", "value": "https://discord.gg/asset"},
+		{"op": "move", "from": "/social/github", "path": "/social/gitlab"},
+		{"op": "replace", "path": "/blockchain", "value": "kusama"}
+	]`))
+
+	require.NoError(t, err)
+	assert.Nil(t, fields.Description)
+	assert.Equal(t, model.KUSAMA, *fields.Blockchain)
+	assert.Equal(t, map[string]string{
+		"twitter": "https://x.com/asset",
+		"discord": "https://discord.gg/asset",
+		"gitlab":  "https://github.com/asset",
+	}, *fields.Social)
+}
+
+func TestAsset_PatchErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		mediaType string
+		patch     string
+	}{
+		{name: "merge patch not an object", mediaType: model.MERGE_PATCH, patch: `["description"]`},
+		{name: "unknown field", mediaType: model.MERGE_PATCH, patch: `{"address": "other"}`},
+		{name: "remove blockchain", mediaType: model.MERGE_PATCH, patch: `{"blockchain": null}`},
+		{name: "invalid value", mediaType: model.MERGE_PATCH, patch: `{"social": {"twitter": "not a url"}}`},
+		{name: "wrong type", mediaType: model.MERGE_PATCH, patch: `{"description": 1}`},
+		{name: "json patch not an array", mediaType: model.JSON_PATCH, patch: `{"op": "remove"}`},
+		{name: "failed test", mediaType: model.JSON_PATCH, patch: `[{"op": "test", "path": "/description", "value": "other"}]`},
+		{name: "remove missing", mediaType: model.JSON_PATCH, patch: `[{"op": "remove", "path": "/social/discord"}]`},
+		{name: "missing value", mediaType: model.JSON_PATCH, patch: `[{"op": "add", "path": "/description"}]`},
+		{name: "missing parent", mediaType: model.JSON_PATCH, patch: `[{"op": "add", "path": "/links/web", "value": "https://example.com"}]`},
+		{name: "unknown op", mediaType: model.JSON_PATCH, patch: `[{"op": "merge", "path": "/description"}]`},
+		{name: "unknown media type", mediaType: "application/json", patch: `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := patchableAsset().Patch(tt.mediaType, json.RawMessage(tt.patch))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPatchAssetInput_MediaType(t *testing.T) {
+	input := model.PatchAssetInput{ContentType: "application/merge-patch+json; charset=utf-8"}
+	assert.Equal(t, model.MERGE_PATCH, input.MediaType())
+	input.ContentType = model.JSON_PATCH
+	assert.Equal(t, model.JSON_PATCH, input.MediaType())
+	input.ContentType = "application/json"
+	assert.Equal(t, "", input.MediaType())
+}
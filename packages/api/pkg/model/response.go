@@ -8,6 +8,24 @@ type Response struct {
 	OK      bool            `json:"ok"`
 	Message string          `json:"message,omitempty"`
 	Data    json.RawMessage `json:"data,omitempty"`
+	Meta    *Meta           `json:"meta,omitempty"`
+}
+
+// Meta describes the page of a list response. Total is only set when the count is requested.
+type Meta struct {
+	Total      *int   `json:"total,omitempty"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// WithMeta returns the response with the page metadata.
+func (r Response) WithMeta(meta *Meta) Response {
+	if r.OK {
+		r.Meta = meta
+	}
+	return r
 }
 
 func NewResponseError(message string) Response {
@@ -45,3 +45,17 @@ func TestNewResponseEmpty(t *testing.T) {
 	assert.True(t, resp.OK)
 	assert.Nil(t, resp.Data)
 }
+
+func TestResponseWithMeta(t *testing.T) {
+	total := 3
+	meta := &model.Meta{Total: &total, Limit: 2, HasMore: true}
+
+	resp := model.NewResponseData([]string{"a", "b"}).WithMeta(meta)
+	assert.Equal(t, meta, resp.Meta)
+
+	encoded, err := json.Marshal(resp)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"ok":true,"data":["a","b"],"meta":{"total":3,"limit":2,"offset":0,"has_more":true}}`, string(encoded))
+
+	assert.Nil(t, model.NewResponseError("error").WithMeta(meta).Meta)
+}
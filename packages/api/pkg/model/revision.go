@@ -0,0 +1,95 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// AssetRevision is the state of an asset after a change, and who made it.
+type AssetRevision struct {
+	bun.BaseModel `bun:"table:asset_revisions,alias:r"`
+	ID            *int64  `bun:"id,pk,autoincrement" json:"-"`
+	AssetID       string  `bun:"asset_id,notnull" json:"asset_id"`
+	Revision      int     `bun:"revision,notnull" json:"revision"`
+	Operation     string  `bun:"operation,notnull" json:"operation"`
+	Address       string  `bun:"address,notnull" json:"address"`
+	AuthMethod    string  `bun:"auth_method,notnull" json:"auth_method"`
+	Nonce         *string `bun:"nonce" json:"nonce,omitempty"`
+	// Snapshot is the asset after the operation, read from the database when the revision is stored.
+	Snapshot  *Asset    `bun:"snapshot,type:jsonb" json:"asset"`
+	CreatedAt time.Time `bun:"created_at" json:"created_at"`
+}
+
+// NewAssetRevision returns the revision of the operation of the signer on the asset, without its snapshot.
+func NewAssetRevision(assetID, operation string, signer *Principal) *AssetRevision {
+	return &AssetRevision{
+		AssetID:    assetID,
+		Operation:  operation,
+		Address:    signer.Address,
+		AuthMethod: signer.AuthMethod,
+		Nonce:      nonceOf(signer),
+	}
+}
+
+// FieldChange is a field of an asset that differs between two revisions. Missing values are null.
+type FieldChange struct {
+	Field string          `json:"field"`
+	From  json.RawMessage `json:"from"`
+	To    json.RawMessage `json:"to"`
+}
+
+type AssetDiff struct {
+	AssetID string        `json:"asset_id"`
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// DiffRevisions returns the fields of the asset that changed from a revision to another, sorted by name.
+func DiffRevisions(from, to *AssetRevision) (*AssetDiff, error) {
+	fromFields, err := snapshotFields(from.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := snapshotFields(to.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, 0, len(toFields))
+	for field := range toFields {
+		fields = append(fields, field)
+	}
+	for field := range fromFields {
+		if _, ok := toFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	diff := &AssetDiff{AssetID: to.AssetID, From: from.Revision, To: to.Revision, Changes: []FieldChange{}}
+	for _, field := range fields {
+		if !bytes.Equal(fromFields[field], toFields[field]) {
+			diff.Changes = append(diff.Changes, FieldChange{Field: field, From: fromFields[field], To: toFields[field]})
+		}
+	}
+	return diff, nil
+}
+
+func snapshotFields(snapshot *Asset) (map[string]json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if snapshot == nil {
+		return fields, nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,47 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAssetRevision(t *testing.T) {
+	revision := model.NewAssetRevision("asset1", model.REVISION_UPDATE, &model.Principal{Address: "owner", AuthMethod: model.AUTH_METHOD_SIGNATURE, Nonce: "nonce"})
+	assert.Equal(t, "owner", revision.Address)
+	require.NotNil(t, revision.Nonce)
+	assert.Equal(t, "nonce", *revision.Nonce)
+
+	revision = model.NewAssetRevision("asset1", model.REVISION_UPDATE, &model.Principal{Address: "owner", AuthMethod: model.AUTH_METHOD_SESSION})
+	assert.Nil(t, revision.Nonce)
+}
+
+func TestDiffRevisions(t *testing.T) {
+	description := "description"
+	image := "https://example.com/image.png"
+	social := map[string]string{"twitter": "handle"}
+	from := &model.AssetRevision{AssetID: "asset1", Revision: 1, Snapshot: &model.Asset{ID: "asset1", Address: "owner", Description: &description}}
+	to := &model.AssetRevision{AssetID: "asset1", Revision: 3, Snapshot: &model.Asset{ID: "asset1", Address: "owner", Image: &image, Social: &social}}
+
+	diff, err := model.DiffRevisions(from, to)
+	require.NoError(t, err)
+	data, err := json.Marshal(diff)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"asset_id": "asset1",
+		"from": 1,
+		"to": 3,
+		"changes": [
+			{"field": "description", "from": "description", "to": null},
+			{"field": "image", "from": null, "to": "https://example.com/image.png"},
+			{"field": "social", "from": null, "to": {"twitter": "handle"}}
+		]
+	}`, string(data))
+
+	diff, err = model.DiffRevisions(from, from)
+	require.NoError(t, err)
+	assert.Empty(t, diff.Changes)
+}
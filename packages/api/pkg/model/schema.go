@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/ggicci/httpin"
@@ -14,6 +16,8 @@ import (
 // Constants
 const (
 	MaxDescriptionLength = 1000
+	MaxSearchLength      = 200
+	MaxFilterValues      = 20
 	MaxFileSize          = 5 * 1024 * 1024 // 5MB
 	Base58Pattern        = `^[1-9A-HJ-NP-Za-km-z]+$`
 	AlphanumericPattern  = `^[a-zA-Z0-9]+$`
@@ -52,15 +56,34 @@ func validateDescription(description *string) error {
 	}
 	return nil
 }
+
+// validateBlockchain checks that the blockchain is the name of a network. Whether assets can be
+// registered on it depends on the registry of the networks, which the app checks.
 func validateBlockchain(blockchain *string) error {
-	if blockchain != nil {
-		if *blockchain != POLKADOT && *blockchain != KUSAMA {
-			return errors.New("blockchain must be either 'polkadot' or 'kusama'")
-		}
+	if blockchain != nil && !networkNamePattern.MatchString(*blockchain) {
+		return fmt.Errorf("blockchain '%s' is not a network name", *blockchain)
+	}
+	return nil
+}
+
+// validateAddress checks that the address is an SS58 address with a valid checksum.
+func validateAddress(address string) error {
+	if _, _, err := DecodeAddress(address); err != nil {
+		return fmt.Errorf("address is invalid: %s", err)
 	}
 	return nil
 }
 
+// normalizeAddress validates the address and replaces it with its canonical encoding.
+func normalizeAddress(address *string) error {
+	canonical, err := CanonicalAddress(*address)
+	if err != nil {
+		return fmt.Errorf("address is invalid: %s", err)
+	}
+	*address = canonical
+	return nil
+}
+
 func validateSocial(social *map[string]string) error {
 	if social != nil {
 		if _, err := json.Marshal(social); err != nil {
@@ -80,6 +103,11 @@ func isBase58(s string) bool {
 	return re.MatchString(s)
 }
 
+func isAlphanumeric(s string) bool {
+	re := regexp.MustCompile(AlphanumericPattern)
+	return re.MatchString(s)
+}
+
 func containsMaliciousContent(s string) bool {
 	htmlRegex := regexp.MustCompile(`(?i)(<script|<iframe|<object|<embed|<form|<input|<img|<svg|<style|<link|<base|<meta|<frame)`)
 	return htmlRegex.MatchString(s)
@@ -93,6 +121,97 @@ type AuthHeaders struct {
 	Message   string `in:"header=x-message"`
 }
 
+type CreateTokenInput struct {
+	Address   string  `in:"query=address"`
+	Action    string  `in:"query=action"`
+	AssetID   *string `in:"query=asset_id"`
+	Format    *string `in:"query=format"`
+	Delegate  *string `in:"query=delegate"`
+	Recipient *string `in:"query=recipient"`
+	APIKeyID  *string `in:"query=api_key_id"`
+	Digest    *string `in:"query=digest"`
+	// IP is the client address, set by the handler from the request.
+	IP string
+}
+
+func (c *CreateTokenInput) Validate() error {
+	if err := validateAddress(c.Address); err != nil {
+		return err
+	}
+	route, ok := actionRoutes[c.Action]
+	if !ok {
+		return fmt.Errorf("action must be one of: %s", strings.Join(actions(), ", "))
+	}
+	if err := c.validateArgument(route, placeholderAssetID, "asset_id", c.AssetID, validateID); err != nil {
+		return err
+	}
+	if err := c.validateArgument(route, placeholderDelegate, "delegate", c.Delegate, validateAddress); err != nil {
+		return err
+	}
+	if err := c.validateArgument(route, placeholderRecipient, "recipient", c.Recipient, validateAddress); err != nil {
+		return err
+	}
+	if err := c.validateArgument(route, placeholderAPIKeyID, "api_key_id", c.APIKeyID, validateAPIKeyID); err != nil {
+		return err
+	}
+	if !actionsWithDigest[c.Action] && c.Digest != nil {
+		return fmt.Errorf("digest is not allowed for action '%s'", c.Action)
+	}
+	if actionsWithDigest[c.Action] {
+		if c.Digest == nil {
+			return fmt.Errorf("digest is required for action '%s'", c.Action)
+		}
+		if err := validateDigest(*c.Digest); err != nil {
+			return fmt.Errorf("digest: %s", err)
+		}
+	}
+	if c.Format != nil && *c.Format != FORMAT_SIWS {
+		return fmt.Errorf("format must be '%s'", FORMAT_SIWS)
+	}
+	return nil
+}
+
+// validateArgument checks that the query argument is given only for actions whose route has its placeholder.
+func (c *CreateTokenInput) validateArgument(route Route, placeholder, name string, value *string, validate func(string) error) error {
+	if !route.requires(placeholder) {
+		if value != nil {
+			return fmt.Errorf("%s is not allowed for action '%s'", name, c.Action)
+		}
+		return nil
+	}
+	if value == nil {
+		return fmt.Errorf("%s is required for action '%s'", name, c.Action)
+	}
+	if err := validate(*value); err != nil {
+		return fmt.Errorf("%s: %s", name, err)
+	}
+	return nil
+}
+
+// IsSIWS returns true when a Sign-In-With-Substrate message was requested.
+func (c *CreateTokenInput) IsSIWS() bool {
+	return c.Format != nil && *c.Format == FORMAT_SIWS
+}
+
+// Route returns the method and path the requested nonce will be bound to.
+// It must be called after Validate.
+func (c *CreateTokenInput) Route() Route {
+	route := actionRoutes[c.Action]
+	if c.AssetID != nil {
+		route = route.with(placeholderAssetID, *c.AssetID)
+	}
+	if c.Delegate != nil {
+		route = route.with(placeholderDelegate, *c.Delegate)
+	}
+	if c.Recipient != nil {
+		route = route.with(placeholderRecipient, *c.Recipient)
+	}
+	if c.APIKeyID != nil {
+		route = route.with(placeholderAPIKeyID, *c.APIKeyID)
+	}
+	return route
+}
+
 type NewAsset struct {
 	ID          string             `json:"id"`
 	Blockchain  string             `json:"blockchain"`
@@ -111,7 +230,6 @@ type UpdateAsset struct {
 // Input structs with validations
 
 type CreateAssetInput struct {
-	*AuthHeaders
 	NewAsset `in:"body=json;nonzero"`
 }
 
@@ -136,15 +254,18 @@ func (c *CreateAssetInput) Validate() error {
 }
 
 type UpdateAssetInput struct {
-	*AuthHeaders
 	ID          string `in:"path=id"`
 	UpdateAsset `in:"body=json;nonzero"`
+	Precondition
 }
 
 func (c *UpdateAssetInput) Validate() error {
 	if err := validateID(c.ID); err != nil {
 		return err
 	}
+	if err := c.Precondition.Validate(); err != nil {
+		return err
+	}
 	if err := validateImage(c.Image); err != nil {
 		return err
 	}
@@ -161,32 +282,236 @@ func (c *UpdateAssetInput) Validate() error {
 }
 
 type DeleteAssetInput struct {
-	*AuthHeaders
 	ID string `in:"path=id"`
+	Precondition
 }
 
 func (c *DeleteAssetInput) Validate() error {
 	if err := validateID(c.ID); err != nil {
 		return err
 	}
+	if err := c.Precondition.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
 
+type RestoreAssetInput struct {
+	ID string `in:"path=id"`
+}
+
+func (c *RestoreAssetInput) Validate() error {
+	return validateID(c.ID)
+}
+
+type GetAssetRevisionsInput struct {
+	ID string `in:"path=id"`
+	Pagination
+}
+
+func (c *GetAssetRevisionsInput) Validate() error {
+	if err := validateID(c.ID); err != nil {
+		return err
+	}
+	if c.Cursor != nil {
+		return errors.New("revisions are paginated with offset, not cursor")
+	}
+	return c.Pagination.Validate()
+}
+
+// GetAssetAsOfInput looks up an asset by its revision, or as it was at a time.
+type GetAssetAsOfInput struct {
+	ID       string     `in:"path=id"`
+	Revision *int       `in:"query=revision"`
+	At       *time.Time `in:"query=at"`
+}
+
+func (c *GetAssetAsOfInput) Validate() error {
+	if err := validateID(c.ID); err != nil {
+		return err
+	}
+	if (c.Revision == nil) == (c.At == nil) {
+		return errors.New("either revision or at is required")
+	}
+	if c.Revision != nil && *c.Revision < 1 {
+		return errors.New("revision must be positive")
+	}
 	return nil
 }
 
-type GetAssetByIDInput struct {
+type DiffAssetRevisionsInput struct {
+	ID   string `in:"path=id"`
+	From int    `in:"query=from"`
+	To   int    `in:"query=to"`
+}
+
+func (c *DiffAssetRevisionsInput) Validate() error {
+	if err := validateID(c.ID); err != nil {
+		return err
+	}
+	if c.From < 1 || c.To < 1 {
+		return errors.New("from and to must be positive revisions")
+	}
+	return nil
+}
+
+type OfferTransferInput struct {
+	ID        string `in:"path=id"`
+	Recipient string `in:"path=recipient"`
+}
+
+func (c *OfferTransferInput) Validate() error {
+	if err := validateID(c.ID); err != nil {
+		return err
+	}
+	if err := normalizeAddress(&c.Recipient); err != nil {
+		return fmt.Errorf("recipient: %s", err)
+	}
+	return nil
+}
+
+// AssetTransferInput identifies the pending transfer of an asset, to accept or cancel it.
+type AssetTransferInput struct {
 	ID string `in:"path=id"`
 }
 
-func (c *GetAssetByIDInput) Validate() error {
+func (c *AssetTransferInput) Validate() error {
 	return validateID(c.ID)
 }
 
+type GetAssetTransfersInput struct {
+	ID string `in:"path=id"`
+	Pagination
+}
+
+func (c *GetAssetTransfersInput) Validate() error {
+	if err := validateID(c.ID); err != nil {
+		return err
+	}
+	if c.Cursor != nil {
+		return errors.New("transfers are paginated with offset, not cursor")
+	}
+	return c.Pagination.Validate()
+}
+
+type SetDelegate struct {
+	Role string `json:"role"`
+}
+
+type SetDelegateInput struct {
+	Address     string `in:"path=address"`
+	SetDelegate `in:"body=json;nonzero"`
+}
+
+func (c *SetDelegateInput) Validate() error {
+	if err := normalizeAddress(&c.Address); err != nil {
+		return err
+	}
+	if _, ok := rolePermissions[c.Role]; !ok {
+		return fmt.Errorf("role must be one of: %s", strings.Join(roles(), ", "))
+	}
+	return nil
+}
+
+type DeleteDelegateInput struct {
+	Address string `in:"path=address"`
+}
+
+func (c *DeleteDelegateInput) Validate() error {
+	return normalizeAddress(&c.Address)
+}
+
+type GetDelegatesInput struct {
+	Owner string `in:"query=owner"`
+}
+
+func (c *GetDelegatesInput) Validate() error {
+	if err := normalizeAddress(&c.Owner); err != nil {
+		return fmt.Errorf("owner: %s", err)
+	}
+	return nil
+}
+
+type GetAssetByIDInput struct {
+	ID          string  `in:"path=id"`
+	IfNoneMatch *string `in:"header=If-None-Match"`
+	Fields
+	// Projection is the parsed fields, set by Validate.
+	Projection []string
+}
+
+func (c *GetAssetByIDInput) Validate() error {
+	if err := validateID(c.ID); err != nil {
+		return err
+	}
+	projection, err := c.Fields.Parse(ASSET_COLUMNS)
+	if err != nil {
+		return err
+	}
+	c.Projection = projection
+	return nil
+}
+
 type GetAssetsInput struct {
-	Address    *string `in:"query=address"`
-	ID         *string `in:"query=id"`
-	Blockchain *string `in:"query=blockchain"`
+	// Address and Blockchain match any of their values.
+	Address    []string `in:"query=address"`
+	ID         *string  `in:"query=id"`
+	IDPrefix   *string  `in:"query=id_prefix"`
+	Blockchain []string `in:"query=blockchain"`
+	// CreatedAfter and CreatedBefore are exclusive, UpdatedSince is inclusive.
+	CreatedAfter  *time.Time `in:"query=created_after"`
+	CreatedBefore *time.Time `in:"query=created_before"`
+	UpdatedSince  *time.Time `in:"query=updated_since"`
+	HasImage      *bool      `in:"query=has_image"`
+	// Social matches assets with a link for each of the networks.
+	Social []string `in:"query=social"`
+	// Q searches the ids and descriptions. Without an explicit order, results are ranked by relevance.
+	Q         *string `in:"query=q"`
+	Highlight *bool   `in:"query=highlight"`
+	// Count requests the total number of matching assets, which can be expensive on big tables.
+	Count *bool `in:"query=count"`
+	Fields
 	Order
 	Pagination
+	// Projection is the parsed fields, Sort is the parsed order ending with _id, and After is the
+	// decoded cursor, set by Validate.
+	Projection []string
+	Sort       []OrderKey
+	After      *Cursor
+	// Deleted also matches the deleted assets. It's only set for admins.
+	Deleted bool
+}
+
+// AdminGetAssetsInput is GetAssetsInput for admins, who can list the deleted assets too.
+type AdminGetAssetsInput struct {
+	GetAssetsInput
+	IncludeDeleted *bool `in:"query=include_deleted"`
+}
+
+func (c *AdminGetAssetsInput) Validate() error {
+	if err := c.GetAssetsInput.Validate(); err != nil {
+		return err
+	}
+	c.Deleted = c.IncludeDeleted != nil && *c.IncludeDeleted
+	return nil
+}
+
+// withTiebreaker ends the keys with _id, in the direction of the first key, to make the order unique.
+func withTiebreaker(keys []OrderKey, ascending bool) []OrderKey {
+	for _, key := range keys {
+		if key.Field == "_id" {
+			return keys
+		}
+	}
+	if len(keys) > 0 {
+		ascending = keys[0].Ascending
+	}
+	return append(keys, OrderKey{Field: "_id", Ascending: ascending})
+}
+
+// IsRanked returns whether the assets are ordered by their relevance to the search.
+func (c *GetAssetsInput) IsRanked() bool {
+	return c.Q != nil && c.Order.Order == nil
 }
 
 func (c *GetAssetsInput) Validate() error {
@@ -195,28 +520,72 @@ func (c *GetAssetsInput) Validate() error {
 			return err
 		}
 	}
-	if c.Address != nil {
-		if len(*c.Address) != 48 {
-			return errors.New("address is invalid")
+	if c.IDPrefix != nil {
+		if err := validateID(*c.IDPrefix); err != nil {
+			return fmt.Errorf("id_prefix: %s", err)
 		}
-		re := regexp.MustCompile(AlphanumericPattern)
-		if !re.MatchString(*c.Address) {
-			return errors.New("address must only contain alphanumeric characters")
+	}
+	if len(c.Address) > MaxFilterValues || len(c.Blockchain) > MaxFilterValues || len(c.Social) > MaxFilterValues {
+		return fmt.Errorf("filters accept at most %d values", MaxFilterValues)
+	}
+	for i := range c.Address {
+		if err := normalizeAddress(&c.Address[i]); err != nil {
+			return err
 		}
 	}
-	if c.Blockchain != nil {
-		if err := validateBlockchain(c.Blockchain); err != nil {
+	for i := range c.Blockchain {
+		if err := validateBlockchain(&c.Blockchain[i]); err != nil {
 			return err
 		}
 	}
-	if c.Order.Order != nil {
-		validOrders := map[string]bool{"id": true, "address": true, "created_at": true}
-		if !validOrders[*c.Order.Order] {
-			return errors.New("order fields are: id, address, and created_at")
+	for _, network := range c.Social {
+		if !isAlphanumeric(network) {
+			return errors.New("social networks must be alphanumeric")
 		}
 	}
-	c.Pagination.Validate()
-	return nil
+	if c.CreatedAfter != nil && c.CreatedBefore != nil && !c.CreatedAfter.Before(*c.CreatedBefore) {
+		return errors.New("created_after must be before created_before")
+	}
+	if c.Q != nil {
+		q := strings.TrimSpace(*c.Q)
+		if q == "" {
+			return errors.New("q cannot be empty")
+		}
+		if utf8.RuneCountInString(q) > MaxSearchLength {
+			return fmt.Errorf("q exceeds the maximum length of %d characters", MaxSearchLength)
+		}
+		c.Q = &q
+	}
+	if c.Highlight != nil && *c.Highlight && c.Q == nil {
+		return errors.New("highlight requires q")
+	}
+	projection, err := c.Fields.Parse(ASSET_COLUMNS)
+	if err != nil {
+		return err
+	}
+	c.Projection = projection
+	keys, err := c.Order.Keys(ASSET_ORDER_COLUMNS)
+	if err != nil {
+		return err
+	}
+	c.Sort = withTiebreaker(keys, c.IsAscending())
+	if c.Cursor != nil {
+		if c.IsRanked() {
+			return errors.New("cursor cannot be used with results ranked by relevance, use an order")
+		}
+		if c.Offset != nil && *c.Offset != 0 {
+			return errors.New("cursor and offset cannot be used together")
+		}
+		cursor, err := DecodeCursor(*c.Cursor)
+		if err != nil {
+			return err
+		}
+		if cursor.Order != OrderString(c.Sort) || len(cursor.Values) != len(c.Sort) {
+			return errors.New("cursor was issued for another order")
+		}
+		c.After = cursor
+	}
+	return c.Pagination.Validate()
 }
 
 type UploadImageInput struct {
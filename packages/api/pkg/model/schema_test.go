@@ -1,7 +1,9 @@
 package model_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/AssetPortal/assets-api/pkg/model"
 	"github.com/ggicci/httpin"
@@ -50,7 +52,7 @@ func TestCreateAssetInputValidation(t *testing.T) {
 				NewAsset: model.NewAsset{
 					ID:         "1a2b3c",
 					Image:      strPtr("https://example.com/image.jpg"),
-					Blockchain: "invalid",
+					Blockchain: "Invalid",
 				},
 			},
 			wantErr: true,
@@ -107,7 +109,7 @@ func TestUpdateAssetInputValidation(t *testing.T) {
 			input: model.UpdateAssetInput{
 				ID: "1a2b3c",
 				UpdateAsset: model.UpdateAsset{
-					Blockchain: strPtr("invalid"),
+					Blockchain: strPtr("Invalid"),
 				},
 			},
 			wantErr: true,
@@ -197,6 +199,389 @@ func TestUploadImageInputValidation(t *testing.T) {
 	}
 }
 
+func TestCreateTokenInputValidation(t *testing.T) {
+	address := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	tests := []struct {
+		name    string
+		input   model.CreateTokenInput
+		wantErr bool
+	}{
+		{
+			name:    "valid create",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_CREATE_ASSET},
+			wantErr: false,
+		},
+		{
+			name:    "valid update",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_UPDATE_ASSET, AssetID: strPtr("1a2b3c")},
+			wantErr: false,
+		},
+		{
+			name:    "missing address",
+			input:   model.CreateTokenInput{Action: model.ACTION_CREATE_ASSET},
+			wantErr: true,
+		},
+		{
+			name:    "unknown action",
+			input:   model.CreateTokenInput{Address: address, Action: "transfer"},
+			wantErr: true,
+		},
+		{
+			name:    "missing asset id",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_DELETE_ASSET},
+			wantErr: true,
+		},
+		{
+			name:    "invalid asset id",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_DELETE_ASSET, AssetID: strPtr("1I0O")},
+			wantErr: true,
+		},
+		{
+			name:    "asset id on create",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_CREATE_ASSET, AssetID: strPtr("1a2b3c")},
+			wantErr: true,
+		},
+		{
+			name:    "valid set delegate",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_SET_DELEGATE, Delegate: strPtr(address)},
+			wantErr: false,
+		},
+		{
+			name:    "missing delegate",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_DELETE_DELEGATE},
+			wantErr: true,
+		},
+		{
+			name:    "delegate on create",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_CREATE_ASSET, Delegate: strPtr(address)},
+			wantErr: true,
+		},
+		{
+			name:    "valid offer transfer",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_OFFER_TRANSFER, AssetID: strPtr("1a2b3c"), Recipient: strPtr(address)},
+			wantErr: false,
+		},
+		{
+			name:    "missing recipient",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_OFFER_TRANSFER, AssetID: strPtr("1a2b3c")},
+			wantErr: true,
+		},
+		{
+			name:    "recipient on accept",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_ACCEPT_TRANSFER, AssetID: strPtr("1a2b3c"), Recipient: strPtr(address)},
+			wantErr: true,
+		},
+		{
+			name:    "valid bulk",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_BULK_ASSETS, Digest: strPtr("3a7bd3e2360a3d29eea436fcfb7e44c735d117c42d1c1835420b6b9942dd4f1b")},
+			wantErr: false,
+		},
+		{
+			name:    "missing digest",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_BULK_ASSETS},
+			wantErr: true,
+		},
+		{
+			name:    "invalid digest",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_BULK_ASSETS, Digest: strPtr("ABC")},
+			wantErr: true,
+		},
+		{
+			name:    "digest on create",
+			input:   model.CreateTokenInput{Address: address, Action: model.ACTION_CREATE_ASSET, Digest: strPtr("3a7bd3e2360a3d29eea436fcfb7e44c735d117c42d1c1835420b6b9942dd4f1b")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateTokenInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateTokenInputRoute(t *testing.T) {
+	input := model.CreateTokenInput{Action: model.ACTION_DELETE_ASSET, AssetID: strPtr("1a2b3c")}
+	route := input.Route()
+	if route.Method != "DELETE" || route.Path != "/assets/1a2b3c" {
+		t.Errorf("CreateTokenInput.Route() = %+v", route)
+	}
+}
+
+func TestCreateTokenInputRouteDelegate(t *testing.T) {
+	input := model.CreateTokenInput{Action: model.ACTION_SET_DELEGATE, Delegate: strPtr("delegateAddress")}
+	route := input.Route()
+	if route.Method != "PUT" || route.Path != "/delegates/delegateAddress" {
+		t.Errorf("CreateTokenInput.Route() = %+v", route)
+	}
+}
+
+func TestCreateTokenInputRouteTransfer(t *testing.T) {
+	input := model.CreateTokenInput{Action: model.ACTION_OFFER_TRANSFER, AssetID: strPtr("1a2b3c"), Recipient: strPtr("recipientAddress")}
+	route := input.Route()
+	if route.Method != "POST" || route.Path != "/assets/1a2b3c/transfer/recipientAddress" {
+		t.Errorf("CreateTokenInput.Route() = %+v", route)
+	}
+}
+
+func TestSetDelegateInputValidation(t *testing.T) {
+	address := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	tests := []struct {
+		name    string
+		input   model.SetDelegateInput
+		wantErr bool
+	}{
+		{name: "valid", input: model.SetDelegateInput{Address: address, SetDelegate: model.SetDelegate{Role: model.ROLE_MEMBER}}},
+		{name: "unknown role", input: model.SetDelegateInput{Address: address, SetDelegate: model.SetDelegate{Role: "admin"}}, wantErr: true},
+		{name: "invalid address", input: model.SetDelegateInput{Address: "short", SetDelegate: model.SetDelegate{Role: model.ROLE_PROXY}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetDelegateInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetAssetsInputCursor(t *testing.T) {
+	internalID := 7
+	cursor := model.NewCursor(&model.Asset{ID_: &internalID, ID: "asset7"}, []model.OrderKey{{Field: "id", Ascending: true}, {Field: "_id", Ascending: true}}).Encode()
+	offset := 10
+	descending := false
+	tests := []struct {
+		name    string
+		input   model.GetAssetsInput
+		wantErr bool
+	}{
+		{name: "matching order", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("id")}, Pagination: model.Pagination{Cursor: &cursor}}},
+		{name: "other order field", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("address")}, Pagination: model.Pagination{Cursor: &cursor}}, wantErr: true},
+		{name: "other direction", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("id"), Ascending: &descending}, Pagination: model.Pagination{Cursor: &cursor}}, wantErr: true},
+		{name: "with offset", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("id")}, Pagination: model.Pagination{Cursor: &cursor, Offset: &offset}}, wantErr: true},
+		{name: "malformed", input: model.GetAssetsInput{Pagination: model.Pagination{Cursor: strPtr("%%%")}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetAssetsInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && tt.input.After == nil {
+				t.Errorf("GetAssetsInput.Validate() did not decode the cursor")
+			}
+		})
+	}
+}
+
+func TestGetAssetsInputOrder(t *testing.T) {
+	descending := false
+	tests := []struct {
+		name    string
+		input   model.GetAssetsInput
+		want    string
+		wantErr bool
+	}{
+		{name: "default", input: model.GetAssetsInput{}, want: "_id:asc"},
+		{name: "descending default", input: model.GetAssetsInput{Order: model.Order{Ascending: &descending}}, want: "_id:desc"},
+		{name: "single field", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("created_at")}}, want: "created_at:asc,_id:asc"},
+		{name: "single field descending", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("id"), Ascending: &descending}}, want: "id:desc,_id:desc"},
+		{name: "multiple fields", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("created_at:desc, id:ASC")}}, want: "created_at:desc,id:asc,_id:desc"},
+		{name: "explicit tiebreaker", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("_id:desc,blockchain")}}, want: "_id:desc,blockchain:asc"},
+		{name: "unknown field", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("description")}}, wantErr: true},
+		{name: "injection", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("id; DROP TABLE assets")}}, wantErr: true},
+		{name: "unknown direction", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("id:up")}}, wantErr: true},
+		{name: "repeated field", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("id:asc,id:desc")}}, wantErr: true},
+		{name: "empty field", input: model.GetAssetsInput{Order: model.Order{Order: strPtr("id,")}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetAssetsInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := model.OrderString(tt.input.Sort); err == nil && got != tt.want {
+				t.Errorf("GetAssetsInput.Validate() sort = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAssetsInputFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  string
+		want    []string
+		wantErr bool
+	}{
+		{name: "fields", fields: "id, image,blockchain", want: []string{"id", "image", "blockchain"}},
+		{name: "repeated field", fields: "id,id", want: []string{"id"}},
+		{name: "unknown field", fields: "id,search", wantErr: true},
+		{name: "empty field", fields: "id,", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := model.GetAssetsInput{Fields: model.Fields{Fields: &tt.fields}}
+			single := model.GetAssetByIDInput{ID: "abc", Fields: model.Fields{Fields: &tt.fields}}
+			for _, err := range []error{list.Validate(), single.Validate()} {
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if !tt.wantErr && (strings.Join(list.Projection, ",") != strings.Join(tt.want, ",") || strings.Join(single.Projection, ",") != strings.Join(tt.want, ",")) {
+				t.Errorf("Validate() projection = %v and %v, want %v", list.Projection, single.Projection, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAssetsInputSearch(t *testing.T) {
+	highlight := true
+	internalID := 7
+	cursor := model.NewCursor(&model.Asset{ID_: &internalID}, []model.OrderKey{{Field: "_id", Ascending: true}}).Encode()
+	tests := []struct {
+		name    string
+		input   model.GetAssetsInput
+		wantErr bool
+	}{
+		{name: "query", input: model.GetAssetsInput{Q: strPtr(" dot token ")}},
+		{name: "query with highlight", input: model.GetAssetsInput{Q: strPtr("dot"), Highlight: &highlight}},
+		{name: "blank query", input: model.GetAssetsInput{Q: strPtr("   ")}, wantErr: true},
+		{name: "long query", input: model.GetAssetsInput{Q: strPtr(strings.Repeat("a", model.MaxSearchLength+1))}, wantErr: true},
+		{name: "highlight without query", input: model.GetAssetsInput{Highlight: &highlight}, wantErr: true},
+		{name: "cursor with ranked results", input: model.GetAssetsInput{Q: strPtr("dot"), Pagination: model.Pagination{Cursor: &cursor}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetAssetsInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetAssetsInputSearchTrimmed(t *testing.T) {
+	input := model.GetAssetsInput{Q: strPtr(" dot token ")}
+	if err := input.Validate(); err != nil {
+		t.Fatalf("GetAssetsInput.Validate() error = %v", err)
+	}
+	if *input.Q != "dot token" {
+		t.Errorf("GetAssetsInput.Validate() q = %q, want %q", *input.Q, "dot token")
+	}
+	if !input.IsRanked() {
+		t.Errorf("GetAssetsInput.IsRanked() = false, want true")
+	}
+}
+
+func TestGetAssetsInputFilters(t *testing.T) {
+	address := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	before := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	after := before.Add(-24 * time.Hour)
+	tests := []struct {
+		name    string
+		input   model.GetAssetsInput
+		wantErr bool
+	}{
+		{name: "multiple addresses", input: model.GetAssetsInput{Address: []string{address, address}}},
+		{name: "invalid address", input: model.GetAssetsInput{Address: []string{address, "short"}}, wantErr: true},
+		{name: "invalid checksum", input: model.GetAssetsInput{Address: []string{"5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ"}}, wantErr: true},
+		{name: "multiple blockchains", input: model.GetAssetsInput{Blockchain: []string{model.POLKADOT, model.KUSAMA}}},
+		{name: "invalid blockchain", input: model.GetAssetsInput{Blockchain: []string{"Ethereum"}}, wantErr: true},
+		{name: "too many values", input: model.GetAssetsInput{Blockchain: make([]string, model.MaxFilterValues+1)}, wantErr: true},
+		{name: "id prefix", input: model.GetAssetsInput{IDPrefix: strPtr("abc")}},
+		{name: "id prefix with wildcard", input: model.GetAssetsInput{IDPrefix: strPtr("ab%")}, wantErr: true},
+		{name: "created range", input: model.GetAssetsInput{CreatedAfter: &after, CreatedBefore: &before}},
+		{name: "inverted created range", input: model.GetAssetsInput{CreatedAfter: &before, CreatedBefore: &after}, wantErr: true},
+		{name: "social networks", input: model.GetAssetsInput{Social: []string{"twitter", "telegram"}}},
+		{name: "invalid social network", input: model.GetAssetsInput{Social: []string{"twitter'--"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetAssetsInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdminGetAssetsInputIncludeDeleted(t *testing.T) {
+	include := true
+	input := model.AdminGetAssetsInput{IncludeDeleted: &include}
+	if err := input.Validate(); err != nil || !input.Deleted {
+		t.Errorf("AdminGetAssetsInput.Validate() error = %v, Deleted = %v, want deleted assets", err, input.Deleted)
+	}
+
+	input = model.AdminGetAssetsInput{}
+	if err := input.Validate(); err != nil || input.Deleted {
+		t.Errorf("AdminGetAssetsInput.Validate() error = %v, Deleted = %v, want no deleted assets", err, input.Deleted)
+	}
+
+	input = model.AdminGetAssetsInput{IncludeDeleted: &include, GetAssetsInput: model.GetAssetsInput{Blockchain: []string{"Ethereum"}}}
+	if err := input.Validate(); err == nil {
+		t.Error("AdminGetAssetsInput.Validate() expected an error for an invalid blockchain")
+	}
+}
+
+func TestGetAssetAsOfInput_Validate(t *testing.T) {
+	revision, zero := 3, 0
+	at := time.Now()
+	tests := []struct {
+		name    string
+		input   model.GetAssetAsOfInput
+		wantErr bool
+	}{
+		{name: "revision", input: model.GetAssetAsOfInput{ID: "asset1", Revision: &revision}},
+		{name: "time", input: model.GetAssetAsOfInput{ID: "asset1", At: &at}},
+		{name: "neither", input: model.GetAssetAsOfInput{ID: "asset1"}, wantErr: true},
+		{name: "both", input: model.GetAssetAsOfInput{ID: "asset1", Revision: &revision, At: &at}, wantErr: true},
+		{name: "zero revision", input: model.GetAssetAsOfInput{ID: "asset1", Revision: &zero}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetAssetAsOfInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetAssetRevisionsInput_Validate(t *testing.T) {
+	limit, zero, negative := 10, 0, -1
+	tests := []struct {
+		name    string
+		input   model.GetAssetRevisionsInput
+		wantErr bool
+	}{
+		{name: "defaults", input: model.GetAssetRevisionsInput{ID: "asset1"}},
+		{name: "limit", input: model.GetAssetRevisionsInput{ID: "asset1", Pagination: model.Pagination{Limit: &limit}}},
+		{name: "zero limit", input: model.GetAssetRevisionsInput{ID: "asset1", Pagination: model.Pagination{Limit: &zero}}, wantErr: true},
+		{name: "negative limit", input: model.GetAssetRevisionsInput{ID: "asset1", Pagination: model.Pagination{Limit: &negative}}, wantErr: true},
+		{name: "negative offset", input: model.GetAssetRevisionsInput{ID: "asset1", Pagination: model.Pagination{Offset: &negative}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetAssetRevisionsInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 // Helper function
 func strPtr(s string) *string {
 	return &s
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/uptrace/bun"
+)
+
+type Session struct {
+	bun.BaseModel    `bun:"table:sessions,alias:s"`
+	ID               string     `bun:"id,pk" json:"id"`
+	Address          string     `bun:"address,notnull" json:"address"`
+	Network          string     `bun:"network" json:"network,omitempty"`
+	KeyType          string     `bun:"key_type" json:"key_type,omitempty"`
+	RefreshTokenHash string     `bun:"refresh_token_hash,unique,notnull" json:"-"`
+	CreatedAt        time.Time  `bun:"created_at" json:"created_at"`
+	ExpiresAt        time.Time  `bun:"expires_at" json:"expires_at"`
+	RevokedAt        *time.Time `bun:"revoked_at" json:"revoked_at,omitempty"`
+}
+
+func (s *Session) IsValid() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
+
+// SessionTokens are returned when a session is created or refreshed.
+type SessionTokens struct {
+	TokenType        string    `json:"token_type"`
+	AccessToken      string    `json:"access_token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// SessionClaims are the claims of a session access token.
+// The session id is stored in the jti claim and the address in the sub claim.
+type SessionClaims struct {
+	Network string `json:"network,omitempty"`
+	KeyType string `json:"key_type,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func NewSessionClaims(session *Session, issuedAt, expiresAt time.Time) *SessionClaims {
+	return &SessionClaims{
+		Network: session.Network,
+		KeyType: session.KeyType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        session.ID,
+			Subject:   session.Address,
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+}
+
+// Sign returns the claims as an HS256 signed JWT.
+func (c *SessionClaims) Sign(secret []byte) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString(secret)
+}
+
+// ParseSessionToken verifies the signature and expiration of an access token.
+func ParseSessionToken(token string, secret []byte) (*SessionClaims, error) {
+	claims := &SessionClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	if claims.ID == "" || claims.Subject == "" {
+		return nil, errors.New("token is missing the session or the address")
+	}
+	return claims, nil
+}
+
+type RefreshSessionInput struct {
+	RefreshSession `in:"body=json;nonzero"`
+}
+
+type RefreshSession struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (c *RefreshSessionInput) Validate() error {
+	if c.RefreshToken == "" {
+		return errors.New("refresh_token is required")
+	}
+	return nil
+}
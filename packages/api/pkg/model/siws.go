@@ -0,0 +1,208 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const SIWS_VERSION = "1"
+
+const siwsHeaderSuffix = " wants you to sign in with your Substrate account:"
+
+// SIWSMessage is a Sign-In-With-Substrate message, modeled after EIP-4361.
+// It is what wallets show to the user when signing a nonce.
+//
+//	{domain} wants you to sign in with your Substrate account:
+//	{address}
+//
+//	{statement}
+//
+//	URI: {uri}
+//	Version: 1
+//	Chain ID: {chain}
+//	Nonce: {nonce}
+//	Issued At: {issued at}
+//	Expiration Time: {expiration time}
+//	Resources:
+//	- {method} {path}
+type SIWSMessage struct {
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        string
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime time.Time
+	Resource       Route
+}
+
+// NewSIWSMessage builds the message that a token must be signed as.
+func NewSIWSMessage(token *Token, domain, uri, chainID, statement string) *SIWSMessage {
+	return &SIWSMessage{
+		Domain:         domain,
+		Address:        token.Address,
+		Statement:      statement,
+		URI:            uri,
+		Version:        SIWS_VERSION,
+		ChainID:        chainID,
+		Nonce:          token.Token,
+		IssuedAt:       token.CreatedAt,
+		ExpirationTime: token.ExpiresAt,
+		Resource:       Route{Method: token.Method, Path: token.Path},
+	}
+}
+
+func (m *SIWSMessage) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + siwsHeaderSuffix + "\n")
+	b.WriteString(m.Address + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n\n")
+	}
+	b.WriteString("URI: " + m.URI + "\n")
+	b.WriteString("Version: " + m.Version + "\n")
+	b.WriteString("Chain ID: " + m.ChainID + "\n")
+	b.WriteString("Nonce: " + m.Nonce + "\n")
+	b.WriteString("Issued At: " + formatSIWSTime(m.IssuedAt) + "\n")
+	b.WriteString("Expiration Time: " + formatSIWSTime(m.ExpirationTime) + "\n")
+	b.WriteString("Resources:\n")
+	b.WriteString("- " + m.Resource.Method + " " + m.Resource.Path)
+	return b.String()
+}
+
+// ParseSIWSMessage parses a message produced by SIWSMessage.String.
+func ParseSIWSMessage(message string) (*SIWSMessage, error) {
+	lines := strings.Split(message, "\n")
+	m := &SIWSMessage{}
+
+	next := func() (string, error) {
+		if len(lines) == 0 {
+			return "", errors.New("message is incomplete")
+		}
+		line := lines[0]
+		lines = lines[1:]
+		return line, nil
+	}
+	field := func(name string) (string, error) {
+		line, err := next()
+		if err != nil {
+			return "", err
+		}
+		if !strings.HasPrefix(line, name+": ") {
+			return "", fmt.Errorf("expected field '%s'", name)
+		}
+		return strings.TrimPrefix(line, name+": "), nil
+	}
+	empty := func() error {
+		line, err := next()
+		if err != nil {
+			return err
+		}
+		if line != "" {
+			return errors.New("expected an empty line")
+		}
+		return nil
+	}
+
+	header, err := next()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(header, siwsHeaderSuffix) {
+		return nil, errors.New("message is not a sign in message")
+	}
+	m.Domain = strings.TrimSuffix(header, siwsHeaderSuffix)
+	if m.Address, err = next(); err != nil {
+		return nil, err
+	}
+	if err := empty(); err != nil {
+		return nil, err
+	}
+	if len(lines) > 0 && !strings.HasPrefix(lines[0], "URI: ") {
+		if m.Statement, err = next(); err != nil {
+			return nil, err
+		}
+		if err := empty(); err != nil {
+			return nil, err
+		}
+	}
+	if m.URI, err = field("URI"); err != nil {
+		return nil, err
+	}
+	if m.Version, err = field("Version"); err != nil {
+		return nil, err
+	}
+	if m.ChainID, err = field("Chain ID"); err != nil {
+		return nil, err
+	}
+	if m.Nonce, err = field("Nonce"); err != nil {
+		return nil, err
+	}
+	issuedAt, err := field("Issued At")
+	if err != nil {
+		return nil, err
+	}
+	if m.IssuedAt, err = time.Parse(time.RFC3339, issuedAt); err != nil {
+		return nil, errors.New("issued at is not a valid time")
+	}
+	expirationTime, err := field("Expiration Time")
+	if err != nil {
+		return nil, err
+	}
+	if m.ExpirationTime, err = time.Parse(time.RFC3339, expirationTime); err != nil {
+		return nil, errors.New("expiration time is not a valid time")
+	}
+	if line, err := next(); err != nil || line != "Resources:" {
+		return nil, errors.New("expected field 'Resources'")
+	}
+	resource, err := next()
+	if err != nil {
+		return nil, err
+	}
+	method, path, found := strings.Cut(strings.TrimPrefix(resource, "- "), " ")
+	if !strings.HasPrefix(resource, "- ") || !found {
+		return nil, errors.New("resource is invalid")
+	}
+	m.Resource = Route{Method: method, Path: path}
+	if len(lines) > 0 {
+		return nil, errors.New("message has unexpected trailing lines")
+	}
+	return m, nil
+}
+
+// Verify checks every field of a signed message against the expected one.
+func (m *SIWSMessage) Verify(expected *SIWSMessage) error {
+	checks := []struct {
+		name          string
+		got, expected string
+	}{
+		{"domain", m.Domain, expected.Domain},
+		{"address", m.Address, expected.Address},
+		{"statement", m.Statement, expected.Statement},
+		{"URI", m.URI, expected.URI},
+		{"version", m.Version, expected.Version},
+		{"chain ID", m.ChainID, expected.ChainID},
+		{"nonce", m.Nonce, expected.Nonce},
+		{"issued at", formatSIWSTime(m.IssuedAt), formatSIWSTime(expected.IssuedAt)},
+		{"expiration time", formatSIWSTime(m.ExpirationTime), formatSIWSTime(expected.ExpirationTime)},
+		{"resource method", m.Resource.Method, expected.Resource.Method},
+		{"resource path", m.Resource.Path, expected.Resource.Path},
+	}
+	for _, check := range checks {
+		if check.got != check.expected {
+			return fmt.Errorf("%s does not match", check.name)
+		}
+	}
+	if time.Now().After(m.ExpirationTime) {
+		return errors.New("message has expired")
+	}
+	return nil
+}
+
+func formatSIWSTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,100 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func siwsToken() *model.Token {
+	now := time.Now().Truncate(time.Second)
+	return &model.Token{
+		Token:     "db1dce7837b0976fe042cda63c8129e4ea396407158cc494c4b1250f368d58a8",
+		Address:   "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5",
+		Action:    model.ACTION_UPDATE_ASSET,
+		Method:    "PUT",
+		Path:      "/assets/1a2b3c",
+		AssetID:   strPtr("1a2b3c"),
+		CreatedAt: now,
+		ExpiresAt: now.Add(5 * time.Minute),
+	}
+}
+
+func TestSIWSMessage_String(t *testing.T) {
+	token := siwsToken()
+	message := model.NewSIWSMessage(token, "assetportal.xyz", "https://assetportal.xyz", model.POLKADOT, token.Statement()).String()
+
+	lines := strings.Split(message, "\n")
+	assert.Equal(t, "assetportal.xyz wants you to sign in with your Substrate account:", lines[0])
+	assert.Equal(t, token.Address, lines[1])
+	assert.Equal(t, "Update the asset 1a2b3c.", lines[3])
+	assert.Contains(t, message, "Chain ID: polkadot\n")
+	assert.Contains(t, message, "Nonce: "+token.Token+"\n")
+	assert.True(t, strings.HasSuffix(message, "Resources:\n- PUT /assets/1a2b3c"))
+}
+
+func TestParseSIWSMessage_RoundTrip(t *testing.T) {
+	token := siwsToken()
+	for _, statement := range []string{token.Statement(), ""} {
+		expected := model.NewSIWSMessage(token, "assetportal.xyz", "https://assetportal.xyz", model.POLKADOT, statement)
+
+		parsed, err := model.ParseSIWSMessage(expected.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, expected.String(), parsed.String())
+		assert.NoError(t, parsed.Verify(expected))
+	}
+}
+
+func TestParseSIWSMessage_Invalid(t *testing.T) {
+	token := siwsToken()
+	valid := model.NewSIWSMessage(token, "assetportal.xyz", "https://assetportal.xyz", model.POLKADOT, token.Statement()).String()
+	tests := map[string]string{
+		"not a sign in message": token.Token,
+		"missing resources":     strings.Split(valid, "\nResources:")[0],
+		"invalid issued at":     strings.Replace(valid, "Issued At: ", "Issued At: yesterday ", 1),
+		"trailing lines":        valid + "\n- DELETE /assets/1a2b3c",
+		"missing nonce":         strings.Replace(valid, "Nonce: ", "Nonse: ", 1),
+	}
+	for name, message := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := model.ParseSIWSMessage(message)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSIWSMessage_Verify(t *testing.T) {
+	token := siwsToken()
+	expected := model.NewSIWSMessage(token, "assetportal.xyz", "https://assetportal.xyz", model.POLKADOT, token.Statement())
+	tests := map[string]func(m *model.SIWSMessage){
+		"domain":     func(m *model.SIWSMessage) { m.Domain = "phishing.xyz" },
+		"uri":        func(m *model.SIWSMessage) { m.URI = "https://phishing.xyz" },
+		"address":    func(m *model.SIWSMessage) { m.Address = "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F" },
+		"chain":      func(m *model.SIWSMessage) { m.ChainID = model.KUSAMA },
+		"nonce":      func(m *model.SIWSMessage) { m.Nonce = "other" },
+		"expiration": func(m *model.SIWSMessage) { m.ExpirationTime = m.ExpirationTime.Add(time.Hour) },
+		"resource":   func(m *model.SIWSMessage) { m.Resource.Method = "DELETE" },
+	}
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			signed := *expected
+			tamper(&signed)
+			assert.Error(t, signed.Verify(expected))
+		})
+	}
+}
+
+func TestSIWSMessage_VerifyExpired(t *testing.T) {
+	token := siwsToken()
+	token.CreatedAt = token.CreatedAt.Add(-time.Hour)
+	token.ExpiresAt = token.CreatedAt.Add(5 * time.Minute)
+	expected := model.NewSIWSMessage(token, "assetportal.xyz", "https://assetportal.xyz", model.POLKADOT, token.Statement())
+	signed := *expected
+
+	assert.Error(t, signed.Verify(expected))
+}
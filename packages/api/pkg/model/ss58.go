@@ -0,0 +1,91 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+var ss58Prefix = []byte("SS58PRE")
+
+const (
+	accountIDLength   = 32
+	ss58ChecksumBytes = 2
+	maxSS58Prefix     = 16383
+)
+
+// SS58_CANONICAL_PREFIX is the prefix of the canonical encoding of the addresses, the generic
+// Substrate one, so that an account is the same owner whatever network its address is encoded for.
+const SS58_CANONICAL_PREFIX uint16 = 42
+
+// DecodeAddress decodes an SS58 address into its network prefix and account id.
+// It verifies the Blake2b checksum of the address.
+func DecodeAddress(address string) (uint16, []byte, error) {
+	data, err := base58.Decode(address)
+	if err != nil {
+		return 0, nil, fmt.Errorf("address is not valid base58: %v", err)
+	}
+	if len(data) < 1 {
+		return 0, nil, errors.New("address is empty")
+	}
+
+	var prefix uint16
+	prefixLength := 1
+	switch {
+	case data[0] < 64:
+		prefix = uint16(data[0])
+	case data[0] < 128:
+		if len(data) < 2 {
+			return 0, nil, errors.New("address is too short")
+		}
+		lower := (data[0] << 2) | (data[1] >> 6)
+		upper := data[1] & 0x3f
+		prefix = uint16(lower) | uint16(upper)<<8
+		prefixLength = 2
+	default:
+		return 0, nil, errors.New("address has an invalid prefix")
+	}
+
+	if len(data) != prefixLength+accountIDLength+ss58ChecksumBytes {
+		return 0, nil, errors.New("address has an invalid length")
+	}
+	body := data[:prefixLength+accountIDLength]
+	checksum := ss58Checksum(body)
+	if !bytes.Equal(checksum[:ss58ChecksumBytes], data[prefixLength+accountIDLength:]) {
+		return 0, nil, errors.New("address has an invalid checksum")
+	}
+	return prefix, body[prefixLength:], nil
+}
+
+// EncodeAddress encodes an account id as an SS58 address for the network prefix.
+func EncodeAddress(prefix uint16, accountID []byte) string {
+	var data []byte
+	if prefix < 64 {
+		data = append(data, byte(prefix))
+	} else {
+		data = append(data,
+			byte((prefix&0x00fc)>>2)|0x40,
+			byte(prefix>>8)|byte((prefix&0x0003)<<6),
+		)
+	}
+	data = append(data, accountID...)
+	checksum := ss58Checksum(data)
+	data = append(data, checksum[:ss58ChecksumBytes]...)
+	return base58.Encode(data)
+}
+
+func ss58Checksum(data []byte) [blake2b.Size]byte {
+	return blake2b.Sum512(append(append([]byte{}, ss58Prefix...), data...))
+}
+
+// CanonicalAddress returns the canonical encoding of the address, the one it's stored and queried with.
+func CanonicalAddress(address string) (string, error) {
+	_, accountID, err := DecodeAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return EncodeAddress(SS58_CANONICAL_PREFIX, accountID), nil
+}
@@ -0,0 +1,126 @@
+package model_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Well known //Alice development account
+const (
+	alicePublicKey        = "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+	aliceSubstrateAddress = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	alicePolkadotAddress  = "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5"
+	aliceKusamaAddress    = "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F"
+)
+
+func TestDecodeAddress(t *testing.T) {
+	publicKey, _ := hex.DecodeString(alicePublicKey)
+	tests := []struct {
+		address string
+		prefix  uint16
+	}{
+		{address: aliceSubstrateAddress, prefix: 42},
+		{address: alicePolkadotAddress, prefix: 0},
+		{address: aliceKusamaAddress, prefix: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			prefix, accountID, err := model.DecodeAddress(tt.address)
+			require.NoError(t, err)
+			assert.Equal(t, tt.prefix, prefix)
+			assert.Equal(t, publicKey, accountID)
+			assert.Equal(t, tt.address, model.EncodeAddress(prefix, accountID))
+		})
+	}
+}
+
+func TestDecodeAddressTwoBytePrefix(t *testing.T) {
+	publicKey, _ := hex.DecodeString(alicePublicKey)
+	address := model.EncodeAddress(1284, publicKey)
+	prefix, accountID, err := model.DecodeAddress(address)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(1284), prefix)
+	assert.Equal(t, publicKey, accountID)
+}
+
+func TestDecodeAddressInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"0OIl",
+		"5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ",
+		"5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNeh",
+	}
+	for _, address := range tests {
+		_, _, err := model.DecodeAddress(address)
+		assert.Error(t, err, address)
+	}
+}
+
+func TestAddressNetwork(t *testing.T) {
+	networks := model.DefaultNetworks()
+	assert.Equal(t, model.POLKADOT, networks.AddressNetwork(alicePolkadotAddress))
+	assert.Equal(t, model.KUSAMA, networks.AddressNetwork(aliceKusamaAddress))
+	assert.Equal(t, model.SUBSTRATE, networks.AddressNetwork(aliceSubstrateAddress))
+	assert.Equal(t, "", networks.AddressNetwork("invalid"))
+
+	networks, err := model.NewNetworks([]model.Network{{Name: "westend", SS58Prefix: 42, DisplayName: "Westend", Enabled: true}})
+	require.NoError(t, err)
+	assert.Equal(t, "westend", networks.AddressNetwork(aliceSubstrateAddress))
+}
+
+func TestCanonicalAddress(t *testing.T) {
+	for _, address := range []string{aliceSubstrateAddress, alicePolkadotAddress, aliceKusamaAddress} {
+		canonical, err := model.CanonicalAddress(address)
+		require.NoError(t, err)
+		assert.Equal(t, aliceSubstrateAddress, canonical)
+	}
+	_, err := model.CanonicalAddress("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ")
+	assert.Error(t, err)
+}
+
+func TestNewAddressPrincipal(t *testing.T) {
+	networks := model.DefaultNetworks()
+	principal, err := model.NewAddressPrincipal(aliceKusamaAddress, model.AUTH_METHOD_SIGNATURE, networks)
+	require.NoError(t, err)
+	assert.Equal(t, aliceSubstrateAddress, principal.Address)
+	assert.Equal(t, model.KUSAMA, principal.Network)
+	assert.Equal(t, uint16(2), *principal.SS58Prefix)
+	assert.NoError(t, principal.CheckNetwork(networks.Get(model.KUSAMA)))
+	assert.ErrorContains(t, principal.CheckNetwork(networks.Get(model.POLKADOT)), "Polkadot addresses use 0")
+
+	_, err = model.NewAddressPrincipal("invalid", model.AUTH_METHOD_SIGNATURE, networks)
+	assert.Error(t, err)
+
+	// The prefix of API keys is unknown.
+	apiKey := &model.Principal{Address: aliceSubstrateAddress, AuthMethod: model.AUTH_METHOD_API_KEY}
+	assert.NoError(t, apiKey.CheckNetwork(networks.Get(model.POLKADOT)))
+}
+
+func TestAddressInputsNormalization(t *testing.T) {
+	polkadot, kusama, canonical := alicePolkadotAddress, aliceKusamaAddress, aliceSubstrateAddress
+
+	filter := model.GetAssetsInput{Address: []string{polkadot, kusama}}
+	require.NoError(t, filter.Validate())
+	assert.Equal(t, []string{canonical, canonical}, filter.Address)
+
+	delegate := model.SetDelegateInput{Address: kusama, SetDelegate: model.SetDelegate{Role: model.ROLE_MEMBER}}
+	require.NoError(t, delegate.Validate())
+	assert.Equal(t, canonical, delegate.Address)
+
+	owner := model.GetDelegatesInput{Owner: polkadot}
+	require.NoError(t, owner.Validate())
+	assert.Equal(t, canonical, owner.Owner)
+
+	offer := model.OfferTransferInput{ID: "1a2b3c", Recipient: kusama}
+	require.NoError(t, offer.Validate())
+	assert.Equal(t, canonical, offer.Recipient)
+
+	// Nonces are bound to the address as the wallet signs with it.
+	token := model.CreateTokenInput{Address: kusama, Action: model.ACTION_CREATE_ASSET}
+	require.NoError(t, token.Validate())
+	assert.Equal(t, kusama, token.Address)
+}
@@ -1,6 +1,9 @@
 package model
 
 import (
+	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -10,11 +13,129 @@ type Token struct {
 	bun.BaseModel `bun:"table:tokens,alias:t"`
 	ID            *int      `bun:"id" json:"id"`
 	Token         string    `bun:"token,unique,notnull" json:"token"`
+	Address       string    `bun:"address" json:"address"`
+	Action        string    `bun:"action" json:"action"`
+	Method        string    `bun:"method" json:"method"`
+	Path          string    `bun:"path" json:"path"`
+	AssetID       *string   `bun:"asset_id" json:"asset_id,omitempty"`
+	Delegate      *string   `bun:"delegate" json:"delegate,omitempty"`
+	Recipient     *string   `bun:"recipient" json:"recipient,omitempty"`
+	APIKeyID      *string   `bun:"api_key_id" json:"api_key_id,omitempty"`
+	Digest        *string   `bun:"digest" json:"digest,omitempty"`
+	IP            string    `bun:"ip" json:"-"`
 	CreatedAt     time.Time `bun:"created_at" json:"created_at"`
 	ExpiresAt     time.Time `bun:"expires_at" json:"expires_at"`
 	Used          bool      `bun:"used" json:"used"`
+	Message       string    `bun:"-" json:"message,omitempty"`
 }
 
 func (t *Token) IsValid() bool {
-	return !(time.Now().Compare(t.ExpiresAt) > 1 || t.Used)
+	return !t.Used && time.Now().Before(t.ExpiresAt)
+}
+
+// IsIssuedFor checks that the token was requested by the address for the given method and path.
+func (t *Token) IsIssuedFor(address, method, path string) bool {
+	return t.Address == address && t.Method == method && t.Path == path
+}
+
+// Statement returns the human readable statement of the action the token was issued for.
+func (t *Token) Statement() string {
+	statement := actionStatements[t.Action]
+	if t.AssetID != nil {
+		statement = strings.ReplaceAll(statement, placeholderAssetID, *t.AssetID)
+	}
+	if t.Delegate != nil {
+		statement = strings.ReplaceAll(statement, placeholderDelegate, *t.Delegate)
+	}
+	if t.Recipient != nil {
+		statement = strings.ReplaceAll(statement, placeholderRecipient, *t.Recipient)
+	}
+	if t.APIKeyID != nil {
+		statement = strings.ReplaceAll(statement, placeholderAPIKeyID, *t.APIKeyID)
+	}
+	if t.Digest != nil {
+		statement = strings.ReplaceAll(statement, placeholderDigest, *t.Digest)
+	}
+	return statement
+}
+
+// Route is the HTTP method and path that a nonce authorizes.
+type Route struct {
+	Method string
+	Path   string
+}
+
+// Placeholders of the routes, replaced with the query arguments of /nonce.
+const placeholderAssetID = "{id}"
+const placeholderDelegate = "{delegate}"
+const placeholderAPIKeyID = "{api_key_id}"
+const placeholderRecipient = "{recipient}"
+
+// placeholderDigest is not part of a route, it's the digest of the request body.
+const placeholderDigest = "{digest}"
+
+// actionRoutes maps the actions accepted by /nonce to their routes.
+var actionRoutes = map[string]Route{
+	ACTION_CREATE_ASSET:      {Method: http.MethodPost, Path: "/assets"},
+	ACTION_UPDATE_ASSET:      {Method: http.MethodPut, Path: "/assets/{id}"},
+	ACTION_DELETE_ASSET:      {Method: http.MethodDelete, Path: "/assets/{id}"},
+	ACTION_PATCH_ASSET:       {Method: http.MethodPatch, Path: "/assets/{id}"},
+	ACTION_CREATE_SESSION:    {Method: http.MethodPost, Path: "/auth/session"},
+	ACTION_SET_DELEGATE:      {Method: http.MethodPut, Path: "/delegates/{delegate}"},
+	ACTION_DELETE_DELEGATE:   {Method: http.MethodDelete, Path: "/delegates/{delegate}"},
+	ACTION_CREATE_API_KEY:    {Method: http.MethodPost, Path: "/api-keys"},
+	ACTION_LIST_API_KEYS:     {Method: http.MethodGet, Path: "/api-keys"},
+	ACTION_REVOKE_API_KEY:    {Method: http.MethodDelete, Path: "/api-keys/{api_key_id}"},
+	ACTION_BULK_ASSETS:       {Method: http.MethodPost, Path: "/assets/bulk"},
+	ACTION_RESTORE_ASSET:     {Method: http.MethodPost, Path: "/assets/{id}/restore"},
+	ACTION_ADMIN_LIST_ASSETS: {Method: http.MethodGet, Path: "/admin/assets"},
+	ACTION_OFFER_TRANSFER:    {Method: http.MethodPost, Path: "/assets/{id}/transfer/{recipient}"},
+	ACTION_ACCEPT_TRANSFER:   {Method: http.MethodPost, Path: "/assets/{id}/transfer/accept"},
+	ACTION_CANCEL_TRANSFER:   {Method: http.MethodDelete, Path: "/assets/{id}/transfer"},
+}
+
+// actionStatements are the human readable statements of the sign in messages.
+var actionStatements = map[string]string{
+	ACTION_CREATE_ASSET:      "Create a new asset.",
+	ACTION_UPDATE_ASSET:      "Update the asset {id}.",
+	ACTION_DELETE_ASSET:      "Delete the asset {id}.",
+	ACTION_PATCH_ASSET:       "Update the asset {id}.",
+	ACTION_CREATE_SESSION:    "Start a session.",
+	ACTION_SET_DELEGATE:      "Allow {delegate} to manage your assets.",
+	ACTION_DELETE_DELEGATE:   "Remove {delegate} from your delegates.",
+	ACTION_CREATE_API_KEY:    "Create an API key.",
+	ACTION_LIST_API_KEYS:     "List your API keys.",
+	ACTION_REVOKE_API_KEY:    "Revoke the API key {api_key_id}.",
+	ACTION_BULK_ASSETS:       "Apply the batch of asset changes with digest {digest}.",
+	ACTION_RESTORE_ASSET:     "Restore the deleted asset {id}.",
+	ACTION_ADMIN_LIST_ASSETS: "List the assets as an administrator.",
+	ACTION_OFFER_TRANSFER:    "Offer the asset {id} to {recipient}.",
+	ACTION_ACCEPT_TRANSFER:   "Accept the transfer of the asset {id}.",
+	ACTION_CANCEL_TRANSFER:   "Cancel the transfer of the asset {id}.",
+}
+
+// actionsWithDigest are the actions whose nonce is bound to the digest of the request body.
+var actionsWithDigest = map[string]bool{
+	ACTION_BULK_ASSETS: true,
+}
+
+func (r Route) requires(placeholder string) bool {
+	return strings.Contains(r.Path, placeholder)
+}
+
+func (r Route) with(placeholder, value string) Route {
+	return Route{
+		Method: r.Method,
+		Path:   strings.ReplaceAll(r.Path, placeholder, value),
+	}
+}
+
+// actions returns the sorted list of actions accepted by /nonce.
+func actions() []string {
+	names := make([]string, 0, len(actionRoutes))
+	for name := range actionRoutes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
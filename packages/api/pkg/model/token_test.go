@@ -0,0 +1,41 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken_IsValid(t *testing.T) {
+	tests := []struct {
+		name  string
+		token model.Token
+		want  bool
+	}{
+		{name: "unused and not expired", token: model.Token{ExpiresAt: time.Now().Add(time.Minute)}, want: true},
+		{name: "used", token: model.Token{ExpiresAt: time.Now().Add(time.Minute), Used: true}, want: false},
+		{name: "expired", token: model.Token{ExpiresAt: time.Now().Add(-time.Second)}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.token.IsValid())
+		})
+	}
+}
+
+func TestToken_StatementDigest(t *testing.T) {
+	digest := "3a7bd3e2360a3d29eea436fcfb7e44c735d117c42d1c1835420b6b9942dd4f1b"
+	token := model.Token{Action: model.ACTION_BULK_ASSETS, Digest: &digest}
+
+	assert.Equal(t, "Apply the batch of asset changes with digest "+digest+".", token.Statement())
+}
+
+func TestToken_StatementTransfer(t *testing.T) {
+	assetID := "1a2b3c"
+	recipient := "recipientAddress"
+	token := model.Token{Action: model.ACTION_OFFER_TRANSFER, AssetID: &assetID, Recipient: &recipient}
+
+	assert.Equal(t, "Offer the asset 1a2b3c to recipientAddress.", token.Statement())
+}
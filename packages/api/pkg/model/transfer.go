@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// AssetTransfer is an offer of the owner of an asset to transfer it to a recipient. Once accepted
+// or cancelled it's kept as the audit trail of the owners of the asset.
+type AssetTransfer struct {
+	bun.BaseModel `bun:"table:asset_transfers,alias:tr"`
+	ID            *int64     `bun:"id,pk,autoincrement" json:"id"`
+	AssetID       string     `bun:"asset_id,notnull" json:"asset_id"`
+	From          string     `bun:"from_address,notnull" json:"from"`
+	To            string     `bun:"to_address,notnull" json:"to"`
+	Status        string     `bun:"status,notnull" json:"status"`
+	OfferNonce    *string    `bun:"offer_nonce" json:"offer_nonce,omitempty"`
+	AcceptNonce   *string    `bun:"accept_nonce" json:"accept_nonce,omitempty"`
+	CancelledBy   *string    `bun:"cancelled_by" json:"cancelled_by,omitempty"`
+	CreatedAt     time.Time  `bun:"created_at" json:"created_at"`
+	ExpiresAt     time.Time  `bun:"expires_at" json:"expires_at"`
+	CompletedAt   *time.Time `bun:"completed_at" json:"completed_at,omitempty"`
+}
+
+// NewAssetTransfer returns the pending offer of the asset by the signer to the recipient.
+func NewAssetTransfer(assetID, recipient string, signer *Principal, now time.Time, ttl time.Duration) *AssetTransfer {
+	return &AssetTransfer{
+		AssetID:    assetID,
+		From:       signer.Address,
+		To:         recipient,
+		Status:     TRANSFER_PENDING,
+		OfferNonce: nonceOf(signer),
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(ttl),
+	}
+}
+
+// IsExpired returns true if the offer is pending past its expiration.
+func (t *AssetTransfer) IsExpired(now time.Time) bool {
+	return t.Status == TRANSFER_PENDING && !now.Before(t.ExpiresAt)
+}
+
+// Accept completes the transfer on behalf of the signer, who is its recipient.
+func (t *AssetTransfer) Accept(signer *Principal, now time.Time) {
+	t.Status = TRANSFER_ACCEPTED
+	t.AcceptNonce = nonceOf(signer)
+	t.CompletedAt = &now
+}
+
+// Cancel withdraws the offer on behalf of the address, its owner or recipient.
+func (t *AssetTransfer) Cancel(address string, now time.Time) {
+	t.Status = TRANSFER_CANCELLED
+	t.CancelledBy = &address
+	t.CompletedAt = &now
+}
+
+// nonceOf returns the nonce the principal signed, or nil if it was authenticated otherwise.
+func nonceOf(signer *Principal) *string {
+	if signer.Nonce == "" {
+		return nil
+	}
+	nonce := signer.Nonce
+	return &nonce
+}
@@ -0,0 +1,29 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetTransfer_Lifecycle(t *testing.T) {
+	now := time.Now()
+	owner := &model.Principal{Address: "owner", AuthMethod: model.AUTH_METHOD_SIGNATURE, Nonce: "offer"}
+	transfer := model.NewAssetTransfer("asset1", "recipient", owner, now, time.Hour)
+
+	assert.Equal(t, model.TRANSFER_PENDING, transfer.Status)
+	assert.Equal(t, "owner", transfer.From)
+	assert.Equal(t, "offer", *transfer.OfferNonce)
+	assert.False(t, transfer.IsExpired(now))
+	assert.True(t, transfer.IsExpired(now.Add(time.Hour)))
+
+	session := &model.Principal{Address: "recipient", AuthMethod: model.AUTH_METHOD_SESSION}
+	transfer.Accept(session, now)
+	assert.Equal(t, model.TRANSFER_ACCEPTED, transfer.Status)
+	assert.Nil(t, transfer.AcceptNonce)
+	assert.Equal(t, &now, transfer.CompletedAt)
+	// Completed transfers do not expire.
+	assert.False(t, transfer.IsExpired(now.Add(time.Hour)))
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"net/http"
+
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/ggicci/httpin"
+	"github.com/go-chi/render"
+)
+
+// CreateAPIKey mints an API key for the signer. The key is only returned in this response.
+// It requires a signed nonce: sessions and the dev address cannot mint keys.
+func (srv *Service) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.CreateAPIKeyInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	principal, ok := requireWalletPrincipal(w, r)
+	if !ok {
+		return
+	}
+	key, err := srv.apiKeysApp.CreateAPIKey(r.Context(), principal, &input.NewAPIKey)
+	if err != nil {
+		switch err {
+		case appError.ErrAPIKeyExpirationTooLong, appError.ErrTooManyAPIKeys:
+			render.Status(r, http.StatusUnprocessableEntity)
+		case appError.ErrAPIKeyNotSigned:
+			render.Status(r, http.StatusForbidden)
+		default:
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+	} else {
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, model.NewResponseData(key))
+	}
+}
+
+func (srv *Service) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requireWalletPrincipal(w, r)
+	if !ok {
+		return
+	}
+	keys, err := srv.apiKeysApp.GetAPIKeys(r.Context(), principal.Address)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+	} else {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, model.NewResponseData(keys))
+	}
+}
+
+func (srv *Service) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.RevokeAPIKeyInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	principal, ok := requireWalletPrincipal(w, r)
+	if !ok {
+		return
+	}
+	err := srv.apiKeysApp.RevokeAPIKey(r.Context(), input.ID, principal)
+	if err != nil {
+		if err == appError.ErrAPIKeyDoesNotExist {
+			render.Status(r, http.StatusNotFound)
+		} else if err == appError.ErrAPIKeyNotSigned {
+			render.Status(r, http.StatusForbidden)
+		} else {
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+	} else {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, model.NewResponseEmpty())
+	}
+}
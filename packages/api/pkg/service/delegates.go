@@ -0,0 +1,83 @@
+package service
+
+import (
+	"net/http"
+
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/ggicci/httpin"
+	"github.com/go-chi/render"
+)
+
+func (srv *Service) GetDelegates(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.GetDelegatesInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	delegates, err := srv.delegatesApp.GetDelegates(r.Context(), input.Owner)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+	} else {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, model.NewResponseData(delegates))
+	}
+}
+
+// SetDelegate adds or updates a delegate of the signer. Only the owner can manage its delegates,
+// with a signed nonce: sessions and the dev address cannot.
+func (srv *Service) SetDelegate(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.SetDelegateInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	principal, ok := requireWalletPrincipal(w, r)
+	if !ok {
+		return
+	}
+	delegate, err := srv.delegatesApp.SetDelegate(r.Context(), principal, input.Address, input.Role)
+	if err != nil {
+		if err == appError.ErrDelegateIsOwner {
+			render.Status(r, http.StatusUnprocessableEntity)
+		} else if err == appError.ErrDelegateNotSigned {
+			render.Status(r, http.StatusForbidden)
+		} else {
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+	} else {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, model.NewResponseData(delegate))
+	}
+}
+
+func (srv *Service) DeleteDelegate(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.DeleteDelegateInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	principal, ok := requireWalletPrincipal(w, r)
+	if !ok {
+		return
+	}
+	err := srv.delegatesApp.DeleteDelegate(r.Context(), principal, input.Address)
+	if err != nil {
+		if err == appError.ErrDelegateDoesNotExist {
+			render.Status(r, http.StatusNotFound)
+		} else if err == appError.ErrDelegateNotSigned {
+			render.Status(r, http.StatusForbidden)
+		} else {
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+	} else {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, model.NewResponseEmpty())
+	}
+}
@@ -1,20 +1,38 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	appError "github.com/AssetPortal/assets-api/pkg/error"
+	polkadotMiddleware "github.com/AssetPortal/assets-api/pkg/middleware"
 	"github.com/AssetPortal/assets-api/pkg/model"
 	"github.com/ggicci/httpin"
 	"github.com/go-chi/render"
 )
 
 func (srv *Service) CreateToken(w http.ResponseWriter, r *http.Request) {
-	token, err := srv.assetsApp.CreateToken(r.Context())
+	createToken := r.Context().Value(httpin.Input).(*model.CreateTokenInput)
+	if err := createToken.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	createToken.IP = polkadotMiddleware.ClientIPFromContext(r.Context())
+	token, err := srv.assetsApp.CreateToken(r.Context(), createToken)
 	if err != nil {
-		render.Status(r, http.StatusInternalServerError)
+		if err == appError.ErrSIWSDisabled {
+			render.Status(r, http.StatusUnprocessableEntity)
+		} else if err == appError.ErrTooManyOutstandingTokens {
+			// The oldest outstanding nonce expires within the token expiration at most.
+			w.Header().Set("Retry-After", strconv.Itoa(int(srv.assetsApp.Config().TokenExpiration.Seconds())))
+			render.Status(r, http.StatusTooManyRequests)
+		} else {
+			render.Status(r, http.StatusInternalServerError)
+		}
 		render.JSON(w, r, model.NewResponseError(err.Error()))
 	} else {
 		render.Status(r, http.StatusOK)
@@ -22,6 +40,29 @@ func (srv *Service) CreateToken(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// requirePrincipal returns the principal set by the authentication middleware.
+// The request owner must never be taken from the headers.
+func requirePrincipal(w http.ResponseWriter, r *http.Request) (*model.Principal, bool) {
+	principal, ok := polkadotMiddleware.PrincipalFromContext(r.Context())
+	if !ok {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("request is not authenticated"))
+	}
+	return principal, ok
+}
+
+// requireWalletPrincipal returns the principal if it was authenticated with a wallet,
+// so that API keys cannot manage delegates or other API keys.
+func requireWalletPrincipal(w http.ResponseWriter, r *http.Request) (*model.Principal, bool) {
+	principal, ok := requirePrincipal(w, r)
+	if ok && principal.AuthMethod == model.AUTH_METHOD_API_KEY {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, model.NewResponseError("this action cannot be done with an API key"))
+		return nil, false
+	}
+	return principal, ok
+}
+
 func (srv *Service) CreateAsset(w http.ResponseWriter, r *http.Request) {
 	createAsset := r.Context().Value(httpin.Input).(*model.CreateAssetInput)
 	if err := createAsset.Validate(); err != nil {
@@ -29,18 +70,22 @@ func (srv *Service) CreateAsset(w http.ResponseWriter, r *http.Request) {
 		render.JSON(w, r, model.NewResponseError(err.Error()))
 		return
 	}
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
 	asset := &model.Asset{
 		ID:          createAsset.ID,
 		Description: createAsset.Description,
 		Image:       createAsset.Image,
 		Social:      createAsset.Social,
-		Address:     createAsset.Address,
+		Address:     principal.Address,
 		Blockchain:  &createAsset.Blockchain,
 	}
 
-	asset, err := srv.assetsApp.CreateAsset(r.Context(), asset)
+	asset, err := srv.assetsApp.CreateAsset(r.Context(), asset, principal)
 	if err != nil {
-		if err == appError.ErrCreatingAssetIDExists {
+		if err == appError.ErrCreatingAssetIDExists || err == appError.ErrAddressNotOnBlockchain || errors.Is(err, appError.ErrInvalidBlockchain) {
 			render.Status(r, http.StatusUnprocessableEntity)
 		} else {
 			render.Status(r, http.StatusInternalServerError)
@@ -53,6 +98,78 @@ func (srv *Service) CreateAsset(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// BulkAssets applies a batch of creates, updates and deletes. A signed nonce must be bound to the
+// digest of the request body, so that one signature authorizes exactly this batch.
+func (srv *Service) BulkAssets(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.BulkAssetsInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+	if principal.AuthMethod == model.AUTH_METHOD_SIGNATURE && principal.Digest != polkadotMiddleware.BodyDigestFromContext(r.Context()) {
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, model.NewResponseError("the signed nonce was not issued for this batch"))
+		return
+	}
+	for _, item := range input.Items {
+		if !principal.Allows(item.Permission()) {
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, model.NewResponseError("API key lacks the '"+item.Permission()+"' permission"))
+			return
+		}
+	}
+
+	results, err := srv.assetsApp.BulkAssets(r.Context(), &input.BulkAssets, principal)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	response := &model.BulkResponse{Mode: model.BULK_MODE_BEST_EFFORT, Results: results}
+	if input.IsAtomic() {
+		response.Mode = model.BULK_MODE_ATOMIC
+	}
+	for _, result := range results {
+		result.Status = bulkStatus(result)
+		if result.Err != nil {
+			result.Error = result.Err.Error()
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+	if response.Failed > 0 {
+		render.Status(r, http.StatusMultiStatus)
+	} else {
+		render.Status(r, http.StatusOK)
+	}
+	render.JSON(w, r, model.NewResponseData(response))
+}
+
+// bulkStatus returns the status code of the item as if it was its own request.
+func bulkStatus(result *model.BulkResult) int {
+	switch err := result.Err; {
+	case err == nil:
+		if result.Op == model.BULK_CREATE {
+			return http.StatusCreated
+		}
+		return http.StatusOK
+	case err == appError.ErrCreatingAssetIDExists, err == appError.ErrAddressNotOnBlockchain, errors.Is(err, appError.ErrInvalidBlockchain):
+		return http.StatusUnprocessableEntity
+	case err == appError.ErrAssetDoesNotBelongToTheUser:
+		return http.StatusNotFound
+	case err == appError.ErrBulkRolledBack:
+		return http.StatusFailedDependency
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (srv *Service) UpdateAsset(w http.ResponseWriter, r *http.Request) {
 	updateAsset := r.Context().Value(httpin.Input).(*model.UpdateAssetInput)
 	if err := updateAsset.Validate(); err != nil {
@@ -60,29 +177,78 @@ func (srv *Service) UpdateAsset(w http.ResponseWriter, r *http.Request) {
 		render.JSON(w, r, model.NewResponseError(err.Error()))
 		return
 	}
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
 	asset := &model.Asset{
 		ID:          updateAsset.ID,
 		Description: updateAsset.Description,
 		Image:       updateAsset.Image,
 		Social:      updateAsset.Social,
-		Address:     updateAsset.Address,
 		Blockchain:  updateAsset.Blockchain,
 	}
 
-	err := srv.assetsApp.UpdateAsset(r.Context(), asset)
+	err := srv.assetsApp.UpdateAsset(r.Context(), asset, updateAsset.Version, principal)
 	if err != nil {
 		if err == appError.ErrAssetDoesNotBelongToTheUser {
 			render.Status(r, http.StatusNotFound)
+		} else if err == appError.ErrAssetVersionMismatch {
+			render.Status(r, http.StatusPreconditionFailed)
+		} else if err == appError.ErrAddressNotOnBlockchain || errors.Is(err, appError.ErrInvalidBlockchain) {
+			render.Status(r, http.StatusUnprocessableEntity)
 		} else {
 			render.Status(r, http.StatusInternalServerError)
 		}
 		render.JSON(w, r, model.NewResponseError(err.Error()))
 
 	} else {
+		w.Header().Set("ETag", model.ETag(asset.Version))
 		render.Status(r, http.StatusOK)
 		render.JSON(w, r, model.NewResponseEmpty())
 	}
 }
+
+// PatchAsset updates the fields of an asset with a JSON Merge Patch or a JSON Patch, which unlike
+// PUT can remove them.
+func (srv *Service) PatchAsset(w http.ResponseWriter, r *http.Request) {
+	patchAsset := r.Context().Value(httpin.Input).(*model.PatchAssetInput)
+	mediaType := patchAsset.MediaType()
+	if mediaType == "" {
+		render.Status(r, http.StatusUnsupportedMediaType)
+		render.JSON(w, r, model.NewResponseError(fmt.Sprintf("Content-Type must be '%s' or '%s'", model.MERGE_PATCH, model.JSON_PATCH)))
+		return
+	}
+	if err := patchAsset.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	asset, err := srv.assetsApp.PatchAsset(r.Context(), patchAsset.ID, mediaType, patchAsset.Patch, patchAsset.Version, principal)
+	if err != nil {
+		switch {
+		case errors.Is(err, appError.ErrInvalidPatch), errors.Is(err, appError.ErrInvalidBlockchain), err == appError.ErrAddressNotOnBlockchain:
+			render.Status(r, http.StatusUnprocessableEntity)
+		case err == appError.ErrAssetDoesNotBelongToTheUser:
+			render.Status(r, http.StatusNotFound)
+		case err == appError.ErrAssetVersionMismatch:
+			render.Status(r, http.StatusPreconditionFailed)
+		default:
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	w.Header().Set("ETag", model.ETag(asset.Version))
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, model.NewResponseData(asset))
+}
+
 func (srv *Service) DeleteAsset(w http.ResponseWriter, r *http.Request) {
 	deleteAsset := r.Context().Value(httpin.Input).(*model.DeleteAssetInput)
 	if err := deleteAsset.Validate(); err != nil {
@@ -90,11 +256,17 @@ func (srv *Service) DeleteAsset(w http.ResponseWriter, r *http.Request) {
 		render.JSON(w, r, model.NewResponseError(err.Error()))
 		return
 	}
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
 
-	err := srv.assetsApp.DeleteAsset(r.Context(), deleteAsset.ID, deleteAsset.Address)
+	err := srv.assetsApp.DeleteAsset(r.Context(), deleteAsset.ID, deleteAsset.Version, principal)
 	if err != nil {
 		if err == appError.ErrAssetDoesNotBelongToTheUser {
 			render.Status(r, http.StatusNotFound)
+		} else if err == appError.ErrAssetVersionMismatch {
+			render.Status(r, http.StatusPreconditionFailed)
 		} else {
 			render.Status(r, http.StatusInternalServerError)
 		}
@@ -106,6 +278,100 @@ func (srv *Service) DeleteAsset(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (srv *Service) RestoreAsset(w http.ResponseWriter, r *http.Request) {
+	restoreAsset := r.Context().Value(httpin.Input).(*model.RestoreAssetInput)
+	if err := restoreAsset.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	asset, err := srv.assetsApp.RestoreAsset(r.Context(), restoreAsset.ID, principal)
+	if err != nil {
+		if err == appError.ErrAssetNotRestorable {
+			render.Status(r, http.StatusNotFound)
+		} else {
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, model.NewResponseData(asset))
+}
+
+func (srv *Service) GetAssetRevisions(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.GetAssetRevisionsInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	revisions, err := srv.assetsApp.GetRevisions(r.Context(), input.ID, &input.Pagination)
+	if err != nil {
+		if err == appError.ErrHistoryNotFound {
+			render.Status(r, http.StatusNotFound)
+		} else {
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, model.NewResponseData(revisions))
+}
+
+func (srv *Service) GetAssetAsOf(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.GetAssetAsOfInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	revision, err := srv.assetsApp.GetAssetAsOf(r.Context(), input)
+	if err != nil {
+		if err == appError.ErrHistoryNotFound {
+			render.Status(r, http.StatusNotFound)
+		} else {
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	if revision == nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, model.NewResponseError(appError.ErrRevisionDoesNotExist.Error()))
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, model.NewResponseData(revision))
+}
+
+func (srv *Service) DiffAssetRevisions(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.DiffAssetRevisionsInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	diff, err := srv.assetsApp.DiffRevisions(r.Context(), input.ID, input.From, input.To)
+	if err != nil {
+		if err == appError.ErrRevisionDoesNotExist || err == appError.ErrHistoryNotFound {
+			render.Status(r, http.StatusNotFound)
+		} else {
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, model.NewResponseData(diff))
+}
+
 func (srv *Service) GetAssetByID(w http.ResponseWriter, r *http.Request) {
 	getAssetByID := r.Context().Value(httpin.Input).(*model.GetAssetByIDInput)
 	if err := getAssetByID.Validate(); err != nil {
@@ -113,16 +379,46 @@ func (srv *Service) GetAssetByID(w http.ResponseWriter, r *http.Request) {
 		render.JSON(w, r, model.NewResponseError(err.Error()))
 		return
 	}
-	asset, err := srv.assetsApp.GetAssetByID(r.Context(), getAssetByID.ID)
+	asset, err := srv.assetsApp.GetAssetByID(r.Context(), getAssetByID.ID, getAssetByID.Projection)
 	if err != nil {
 		render.Status(r, http.StatusInternalServerError)
 		render.JSON(w, r, model.NewResponseError(err.Error()))
-	} else if asset == nil {
+		return
+	}
+	if asset == nil {
 		render.Status(r, http.StatusNotFound)
 		render.JSON(w, r, model.NewResponseError("asset not found"))
+		return
+	}
+	w.Header().Set("ETag", model.ETag(asset.Version))
+	if getAssetByID.IfNoneMatch != nil && model.MatchesETag(*getAssetByID.IfNoneMatch, asset.Version) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	data, err := asset.Project(getAssetByID.Projection)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, model.NewResponseData(data))
+}
+
+func (srv *Service) BatchGetAssets(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.BatchGetAssetsInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	batch, err := srv.assetsApp.GetAssetsByIDs(r.Context(), input.IDs, input.Projection)
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
 	} else {
 		render.Status(r, http.StatusOK)
-		render.JSON(w, r, model.NewResponseData(asset))
+		render.JSON(w, r, model.NewResponseData(batch))
 	}
 }
 
@@ -133,14 +429,48 @@ func (srv *Service) GetAssets(w http.ResponseWriter, r *http.Request) {
 		render.JSON(w, r, model.NewResponseError(err.Error()))
 		return
 	}
-	assets, err := srv.assetsApp.GetAssets(r.Context(), getAssets)
+	srv.renderAssets(w, r, getAssets)
+}
+
+// AdminGetAssets lists assets like GetAssets, including the deleted ones when requested. It's only for admins.
+func (srv *Service) AdminGetAssets(w http.ResponseWriter, r *http.Request) {
+	getAssets := r.Context().Value(httpin.Input).(*model.AdminGetAssetsInput)
+	if err := getAssets.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	principal, ok := requireWalletPrincipal(w, r)
+	if !ok {
+		return
+	}
+	if !srv.assetsApp.Config().IsAdmin(principal.Address) {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, model.NewResponseError("only admins can do this action"))
+		return
+	}
+	srv.renderAssets(w, r, &getAssets.GetAssetsInput)
+}
+
+func (srv *Service) renderAssets(w http.ResponseWriter, r *http.Request, getAssets *model.GetAssetsInput) {
+	assets, meta, err := srv.assetsApp.GetAssets(r.Context(), getAssets)
+	if err != nil {
+		if errors.Is(err, appError.ErrInvalidBlockchain) {
+			render.Status(r, http.StatusUnprocessableEntity)
+		} else {
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	data, err := model.ProjectAssets(assets, getAssets.Projection)
 	if err != nil {
 		render.Status(r, http.StatusInternalServerError)
 		render.JSON(w, r, model.NewResponseError(err.Error()))
-	} else {
-		render.Status(r, http.StatusOK)
-		render.JSON(w, r, model.NewResponseData(assets))
+		return
 	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, model.NewResponseData(data).WithMeta(meta))
 }
 
 func (srv *Service) UploadImage(w http.ResponseWriter, r *http.Request) {
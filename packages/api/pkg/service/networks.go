@@ -0,0 +1,14 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/go-chi/render"
+)
+
+// GetNetworks returns the networks of the registry, the enabled and the disabled ones.
+func (srv *Service) GetNetworks(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, model.NewResponseData(srv.assetsApp.Networks()))
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -42,12 +44,24 @@ func init() {
 type Service struct {
 	HTTPServer         *http.Server
 	assetsApp          *app.AssetsApp
+	sessionsApp        *app.SessionsApp
+	delegatesApp       *app.DelegatesApp
+	apiKeysApp         *app.APIKeysApp
 	polkadotMiddleware *polkadotMiddleware.PolkadotAuth
 }
 
-func NewService(assetsApp *app.AssetsApp, polkadotMiddleware *polkadotMiddleware.PolkadotAuth) *Service {
+func NewService(
+	assetsApp *app.AssetsApp,
+	sessionsApp *app.SessionsApp,
+	delegatesApp *app.DelegatesApp,
+	apiKeysApp *app.APIKeysApp,
+	polkadotMiddleware *polkadotMiddleware.PolkadotAuth,
+) *Service {
 	return &Service{
 		assetsApp:          assetsApp,
+		sessionsApp:        sessionsApp,
+		delegatesApp:       delegatesApp,
+		apiKeysApp:         apiKeysApp,
 		polkadotMiddleware: polkadotMiddleware,
 	}
 }
@@ -73,6 +87,7 @@ func (srv *Service) Setup() {
 		Debug:            false,
 		AllowCredentials: true,
 		AllowedHeaders:   []string{"*"},
+		ExposedHeaders:   []string{"ETag"},
 		MaxAge:           300,
 	}).Handler)
 
@@ -80,29 +95,131 @@ func (srv *Service) Setup() {
 		render.Status(r, http.StatusOK)
 	})
 
-	router.Get("/nonce", srv.CreateToken)
-	router.With(httpin.NewInput(model.UploadImageInput{})).Post("/upload", srv.UploadImage)
+	router.Get("/networks", srv.GetNetworks)
+
+	nonceLimiter := polkadotMiddleware.NewNonceLimiter(cfg.NonceConfiguration)
+	router.With(nonceLimiter.Middleware).With(
+		httpin.NewInput(model.CreateTokenInput{}),
+	).Get("/nonce", srv.CreateToken)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).Post("/auth/session", srv.CreateSession)
+	router.With(
+		httpin.NewInput(model.RefreshSessionInput{}),
+	).Post("/auth/refresh", srv.RefreshSession)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).Delete("/auth/session", srv.RevokeSession)
+	router.With(srv.polkadotMiddleware.OptionalMiddleware).With(
+		polkadotMiddleware.RequirePermission(model.PERMISSION_UPLOAD),
+	).With(httpin.NewInput(model.UploadImageInput{})).Post("/upload", srv.UploadImage)
 	router.With(
 		httpin.NewInput(model.AuthHeaders{}),
 	).With(srv.polkadotMiddleware.Middleware).With(
+		polkadotMiddleware.RequirePermission(model.PERMISSION_CREATE),
+	).With(
 		httpin.NewInput(model.CreateAssetInput{}),
 	).Post("/assets", srv.CreateAsset)
 	router.With(
 		httpin.NewInput(model.GetAssetsInput{}),
 	).Get("/assets", srv.GetAssets)
+	router.With(
+		httpin.NewInput(model.BatchGetAssetsInput{}),
+	).Post("/assets/batch-get", srv.BatchGetAssets)
+	router.With(polkadotMiddleware.BodyDigest).With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).With(
+		httpin.NewInput(model.BulkAssetsInput{}),
+	).Post("/assets/bulk", srv.BulkAssets)
 	router.With(
 		httpin.NewInput(model.GetAssetByIDInput{}),
 	).Get("/assets/{id}", srv.GetAssetByID)
 	router.With(
 		httpin.NewInput(model.AuthHeaders{}),
 	).With(srv.polkadotMiddleware.Middleware).With(
+		polkadotMiddleware.RequirePermission(model.PERMISSION_UPDATE),
+	).With(
 		httpin.NewInput(model.UpdateAssetInput{}),
 	).Put("/assets/{id}", srv.UpdateAsset)
 	router.With(
 		httpin.NewInput(model.AuthHeaders{}),
 	).With(srv.polkadotMiddleware.Middleware).With(
+		polkadotMiddleware.RequirePermission(model.PERMISSION_UPDATE),
+	).With(
+		httpin.NewInput(model.PatchAssetInput{}),
+	).Patch("/assets/{id}", srv.PatchAsset)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).With(
+		polkadotMiddleware.RequirePermission(model.PERMISSION_DELETE),
+	).With(
 		httpin.NewInput(model.DeleteAssetInput{}),
 	).Delete("/assets/{id}", srv.DeleteAsset)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).With(
+		polkadotMiddleware.RequirePermission(model.PERMISSION_RESTORE),
+	).With(
+		httpin.NewInput(model.RestoreAssetInput{}),
+	).Post("/assets/{id}/restore", srv.RestoreAsset)
+	router.With(
+		httpin.NewInput(model.GetAssetRevisionsInput{}),
+	).Get("/assets/{id}/revisions", srv.GetAssetRevisions)
+	router.With(
+		httpin.NewInput(model.GetAssetAsOfInput{}),
+	).Get("/assets/{id}/as-of", srv.GetAssetAsOf)
+	router.With(
+		httpin.NewInput(model.DiffAssetRevisionsInput{}),
+	).Get("/assets/{id}/diff", srv.DiffAssetRevisions)
+	router.With(
+		httpin.NewInput(model.GetAssetTransfersInput{}),
+	).Get("/assets/{id}/transfers", srv.GetAssetTransfers)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).With(
+		httpin.NewInput(model.AssetTransferInput{}),
+	).Post("/assets/{id}/transfer/accept", srv.AcceptTransfer)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).With(
+		httpin.NewInput(model.OfferTransferInput{}),
+	).Post("/assets/{id}/transfer/{recipient}", srv.OfferTransfer)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).With(
+		httpin.NewInput(model.AssetTransferInput{}),
+	).Delete("/assets/{id}/transfer", srv.CancelTransfer)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).With(
+		httpin.NewInput(model.AdminGetAssetsInput{}),
+	).Get("/admin/assets", srv.AdminGetAssets)
+	router.With(
+		httpin.NewInput(model.GetDelegatesInput{}),
+	).Get("/delegates", srv.GetDelegates)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).With(
+		httpin.NewInput(model.SetDelegateInput{}),
+	).Put("/delegates/{address}", srv.SetDelegate)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).With(
+		httpin.NewInput(model.DeleteDelegateInput{}),
+	).Delete("/delegates/{address}", srv.DeleteDelegate)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).With(
+		httpin.NewInput(model.CreateAPIKeyInput{}),
+	).Post("/api-keys", srv.CreateAPIKey)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).Get("/api-keys", srv.GetAPIKeys)
+	router.With(
+		httpin.NewInput(model.AuthHeaders{}),
+	).With(srv.polkadotMiddleware.Middleware).With(
+		httpin.NewInput(model.RevokeAPIKeyInput{}),
+	).Delete("/api-keys/{id}", srv.RevokeAPIKey)
 
 	srv.HTTPServer = &http.Server{Addr: cfg.ServiceAddress, Handler: router}
 }
@@ -110,7 +227,12 @@ func (srv *Service) Setup() {
 func (srv *Service) Start() {
 	fmt.Printf("Starting API server at %s\n", srv.assetsApp.Config().ServiceAddress)
 	err := srv.HTTPServer.ListenAndServe()
-	if err != nil {
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		panic(err)
 	}
 }
+
+// Stop gracefully shuts down the server, waiting for in-flight requests until the context expires.
+func (srv *Service) Stop(ctx context.Context) error {
+	return srv.HTTPServer.Shutdown(ctx)
+}
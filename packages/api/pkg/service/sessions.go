@@ -0,0 +1,77 @@
+package service
+
+import (
+	"net/http"
+
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/ggicci/httpin"
+	"github.com/go-chi/render"
+)
+
+func (srv *Service) CreateSession(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+	if principal.AuthMethod != model.AUTH_METHOD_SIGNATURE {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, model.NewResponseError("a session can only be created with a signed nonce"))
+		return
+	}
+	tokens, err := srv.sessionsApp.CreateSession(r.Context(), principal)
+	if err != nil {
+		if err == appError.ErrSessionsDisabled {
+			render.Status(r, http.StatusNotImplemented)
+		} else {
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+	} else {
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, model.NewResponseData(tokens))
+	}
+}
+
+func (srv *Service) RefreshSession(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.RefreshSessionInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	tokens, err := srv.sessionsApp.RefreshSession(r.Context(), input.RefreshToken)
+	if err != nil {
+		switch err {
+		case appError.ErrSessionsDisabled:
+			render.Status(r, http.StatusNotImplemented)
+		case appError.ErrInvalidRefreshToken:
+			render.Status(r, http.StatusUnauthorized)
+		default:
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+	} else {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, model.NewResponseData(tokens))
+	}
+}
+
+func (srv *Service) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+	if principal.SessionID == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, model.NewResponseError("request is not authenticated with a session token"))
+		return
+	}
+	if err := srv.sessionsApp.RevokeSession(r.Context(), principal.SessionID); err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+	} else {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, model.NewResponseEmpty())
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"net/http"
+
+	appError "github.com/AssetPortal/assets-api/pkg/error"
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/ggicci/httpin"
+	"github.com/go-chi/render"
+)
+
+func transferErrorStatus(err error) int {
+	switch err {
+	case appError.ErrTransferToOwner:
+		return http.StatusUnprocessableEntity
+	case appError.ErrTransferNotSigned:
+		return http.StatusForbidden
+	case appError.ErrAssetDoesNotBelongToTheUser, appError.ErrTransferDoesNotExist:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// OfferTransfer offers an asset of the signer to the recipient. Only the owner can give its
+// assets away, with a signed nonce: sessions and the dev address cannot.
+func (srv *Service) OfferTransfer(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.OfferTransferInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	principal, ok := requireWalletPrincipal(w, r)
+	if !ok {
+		return
+	}
+	transfer, err := srv.assetsApp.OfferTransfer(r.Context(), input.ID, input.Recipient, principal)
+	if err != nil {
+		render.Status(r, transferErrorStatus(err))
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, model.NewResponseData(transfer))
+}
+
+// AcceptTransfer makes the signer, the recipient of the pending offer, the owner of the asset.
+func (srv *Service) AcceptTransfer(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.AssetTransferInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	principal, ok := requireWalletPrincipal(w, r)
+	if !ok {
+		return
+	}
+	asset, err := srv.assetsApp.AcceptTransfer(r.Context(), input.ID, principal)
+	if err != nil {
+		render.Status(r, transferErrorStatus(err))
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, model.NewResponseData(asset))
+}
+
+// CancelTransfer withdraws the pending offer of an asset. Its owner and its recipient can cancel it.
+func (srv *Service) CancelTransfer(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.AssetTransferInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	principal, ok := requireWalletPrincipal(w, r)
+	if !ok {
+		return
+	}
+	transfer, err := srv.assetsApp.CancelTransfer(r.Context(), input.ID, principal)
+	if err != nil {
+		render.Status(r, transferErrorStatus(err))
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, model.NewResponseData(transfer))
+}
+
+func (srv *Service) GetAssetTransfers(w http.ResponseWriter, r *http.Request) {
+	input := r.Context().Value(httpin.Input).(*model.GetAssetTransfersInput)
+	if err := input.Validate(); err != nil {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	transfers, err := srv.assetsApp.GetTransfers(r.Context(), input.ID, &input.Pagination)
+	if err != nil {
+		if err == appError.ErrHistoryNotFound {
+			render.Status(r, http.StatusNotFound)
+		} else {
+			render.Status(r, http.StatusInternalServerError)
+		}
+		render.JSON(w, r, model.NewResponseError(err.Error()))
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, model.NewResponseData(transfers))
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/AssetPortal/assets-api/pkg/model"
+	"github.com/uptrace/bun"
+)
+
+// addressColumns are the columns of addresses, by table. The addresses of the tokens are left as the
+// wallets signed them, and they expire anyway.
+var addressColumns = []struct {
+	table  string
+	column string
+}{
+	{"assets", "address"},
+	{"asset_revisions", "address"},
+	{"asset_transfers", "from_address"},
+	{"asset_transfers", "to_address"},
+	{"asset_transfers", "cancelled_by"},
+	{"sessions", "address"},
+	{"api_keys", "owner"},
+}
+
+// normalizeAddresses re-encodes the stored addresses with the canonical prefix of the API, so that an account is
+// the same owner whatever network its address was encoded for. Invalid addresses are left as they are.
+// It returns the number of updated rows.
+func normalizeAddresses(ctx context.Context, db *bun.DB) (int64, error) {
+	var total int64
+	err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, c := range addressColumns {
+			updated, err := normalizeColumn(ctx, tx, c.table, c.column, "")
+			if err != nil {
+				return err
+			}
+			total += updated
+		}
+		// The delegates of an owner are unique, so the duplicates left by the normalization are deleted.
+		for _, c := range [][2]string{{"owner", "address"}, {"address", "owner"}} {
+			updated, err := normalizeColumn(ctx, tx, "delegates", c[0], fmt.Sprintf(
+				"AND NOT EXISTS (SELECT 1 FROM delegates d WHERE d.%[1]s = ?0 AND d.%[2]s = delegates.%[2]s)", c[0], c[1],
+			))
+			if err != nil {
+				return err
+			}
+			total += updated
+		}
+		return nil
+	})
+	return total, err
+}
+
+// normalizeColumn updates the addresses of the column that are not canonical. The condition is added
+// to the update, with ?0 as the canonical address. The rows it excludes are deleted.
+func normalizeColumn(ctx context.Context, tx bun.Tx, table, column, condition string) (int64, error) {
+	var addresses []string
+	err := tx.NewSelect().Table(table).ColumnExpr("DISTINCT ?", bun.Ident(column)).
+		Where("? IS NOT NULL", bun.Ident(column)).Scan(ctx, &addresses)
+	if err != nil {
+		return 0, fmt.Errorf("error reading %s.%s: %w", table, column, err)
+	}
+	var total int64
+	for _, address := range addresses {
+		canonical, err := model.CanonicalAddress(address)
+		if err != nil || canonical == address {
+			continue
+		}
+		var res sql.Result
+		res, err = tx.ExecContext(ctx, fmt.Sprintf("UPDATE %[1]s SET %[2]s = ?0 WHERE %[2]s = ?1 %[3]s", table, column, condition), canonical, address)
+		if err != nil {
+			return 0, fmt.Errorf("error normalizing %s.%s: %w", table, column, err)
+		}
+		updated, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		total += updated
+		if condition != "" {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s = ?", table, column), address); err != nil {
+				return 0, fmt.Errorf("error deleting the duplicates of %s.%s: %w", table, column, err)
+			}
+		}
+	}
+	return total, nil
+}
@@ -3,6 +3,8 @@ module github.com/AssetPortal/migrate
 go 1.23.3
 
 require (
+	github.com/AssetPortal/assets-api v0.0.0
+	github.com/sirupsen/logrus v1.9.3
 	github.com/uptrace/bun v1.2.5
 	github.com/uptrace/bun/dialect/pgdialect v1.2.5
 	github.com/uptrace/bun/driver/pgdriver v1.2.5
@@ -10,6 +12,27 @@ require (
 	github.com/urfave/cli/v2 v2.27.5
 )
 
+require (
+	github.com/aws/aws-sdk-go-v2 v1.33.0 // indirect
+	github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream v1.6.7 // indirect
+	github.com/aws/aws-sdk-go-v2/internal/configsources v1.3.28 // indirect
+	github.com/aws/aws-sdk-go-v2/internal/endpoints/v2 v2.6.28 // indirect
+	github.com/aws/aws-sdk-go-v2/internal/v4a v1.3.28 // indirect
+	github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding v1.12.1 // indirect
+	github.com/aws/aws-sdk-go-v2/service/internal/checksum v1.5.2 // indirect
+	github.com/aws/aws-sdk-go-v2/service/internal/presigned-url v1.12.9 // indirect
+	github.com/aws/aws-sdk-go-v2/service/internal/s3shared v1.18.9 // indirect
+	github.com/aws/aws-sdk-go-v2/service/s3 v1.73.2 // indirect
+	github.com/aws/smithy-go v1.22.1 // indirect
+	github.com/caarlos0/env/v11 v11.2.2 // indirect
+	github.com/ggicci/httpin v0.19.0 // indirect
+	github.com/ggicci/owl v0.8.2 // indirect
+	github.com/golang-jwt/jwt/v5 v5.2.1 // indirect
+	github.com/mr-tron/base58 v1.2.0 // indirect
+	github.com/subosito/gotenv v1.6.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+)
+
 require (
 	github.com/cpuguy83/go-md2man/v2 v2.0.5 // indirect
 	github.com/fatih/color v1.18.0 // indirect
@@ -26,3 +49,5 @@ require (
 	golang.org/x/sys v0.28.0 // indirect
 	mellium.im/sasl v0.3.2 // indirect
 )
+
+replace github.com/AssetPortal/assets-api => ../api
@@ -2,12 +2,19 @@ package main
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/AssetPortal/assets-api/pkg/adapters/assets"
+	"github.com/AssetPortal/assets-api/pkg/adapters/tokens"
+	"github.com/AssetPortal/assets-api/pkg/app"
+	"github.com/AssetPortal/assets-api/pkg/config"
 	"github.com/AssetPortal/migrate/migrations"
+	"github.com/sirupsen/logrus"
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/driver/pgdriver"
 	"github.com/uptrace/bun/extra/bundebug"
@@ -33,6 +40,8 @@ func main() {
 
 		Commands: []*cli.Command{
 			newDBCommand(migrate.NewMigrator(db, migrations.Migrations)),
+			newTokensCommand(db),
+			newAssetsCommand(db),
 		},
 	}
 	if err := app.Run(os.Args); err != nil {
@@ -188,3 +197,104 @@ func newDBCommand(migrator *migrate.Migrator) *cli.Command {
 		},
 	}
 }
+
+func newTokensCommand(db *bun.DB) *cli.Command {
+	return &cli.Command{
+		Name:  "tokens",
+		Usage: "token maintenance",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "purge",
+				Usage: "delete expired tokens and used tokens older than the retention window",
+				Flags: []cli.Flag{
+					&cli.DurationFlag{
+						Name:  "retention",
+						Usage: "how long used tokens are kept",
+						Value: 24 * time.Hour,
+					},
+					&cli.IntFlag{
+						Name:  "batch-size",
+						Usage: "maximum number of tokens deleted per statement",
+						Value: 1000,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					janitor, err := newJanitor(db, config.JanitorConfiguration{
+						Retention: c.Duration("retention"),
+						BatchSize: c.Int("batch-size"),
+					}, config.AssetsConfiguration{})
+					if err != nil {
+						return err
+					}
+					total, err := janitor.Purge(c.Context)
+					if err != nil {
+						return err
+					}
+					fmt.Printf("deleted %d tokens\n", total)
+					return nil
+				},
+			},
+		},
+	}
+}
+
+func newAssetsCommand(db *bun.DB) *cli.Command {
+	return &cli.Command{
+		Name:  "assets",
+		Usage: "asset maintenance",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "purge",
+				Usage: "hard delete the assets deleted before the restore window",
+				Flags: []cli.Flag{
+					&cli.DurationFlag{
+						Name:  "restore-window",
+						Usage: "how long deleted assets can be restored",
+						Value: 720 * time.Hour,
+					},
+					&cli.IntFlag{
+						Name:  "batch-size",
+						Usage: "maximum number of assets deleted per statement",
+						Value: 1000,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					janitor, err := newJanitor(db, config.JanitorConfiguration{
+						BatchSize: c.Int("batch-size"),
+					}, config.AssetsConfiguration{RestoreWindow: c.Duration("restore-window")})
+					if err != nil {
+						return err
+					}
+					total, err := janitor.PurgeAssets(c.Context)
+					if err != nil {
+						return err
+					}
+					fmt.Printf("purged %d deleted assets\n", total)
+					return nil
+				},
+			},
+			{
+				Name:  "normalize-addresses",
+				Usage: "re-encode the stored addresses with the canonical SS58 prefix",
+				Action: func(c *cli.Context) error {
+					total, err := normalizeAddresses(c.Context, db)
+					if err != nil {
+						return err
+					}
+					fmt.Printf("normalized %d addresses\n", total)
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// newJanitor returns the janitor of the API, so that the purges run the same statements in the same
+// batches as the background purge.
+func newJanitor(db *bun.DB, janitorCfg config.JanitorConfiguration, assetsCfg config.AssetsConfiguration) (*app.Janitor, error) {
+	if janitorCfg.BatchSize < 1 {
+		return nil, errors.New("--batch-size must be at least 1")
+	}
+	cfg := &config.Configuration{JanitorConfiguration: janitorCfg, AssetsConfiguration: assetsCfg}
+	return app.NewJanitor(cfg, tokens.NewTokensRepository(db), assets.NewAssetsRepository(db), logrus.New()), nil
+}
@@ -0,0 +1,4 @@
+ALTER TABLE tokens DROP COLUMN asset_id;
+ALTER TABLE tokens DROP COLUMN path;
+ALTER TABLE tokens DROP COLUMN method;
+ALTER TABLE tokens DROP COLUMN address;
@@ -0,0 +1,4 @@
+ALTER TABLE tokens ADD COLUMN address TEXT NULL;
+ALTER TABLE tokens ADD COLUMN method TEXT NULL;
+ALTER TABLE tokens ADD COLUMN path TEXT NULL;
+ALTER TABLE tokens ADD COLUMN asset_id TEXT NULL;
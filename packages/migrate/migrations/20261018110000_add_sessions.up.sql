@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS sessions (
+    id TEXT PRIMARY KEY,
+    address TEXT NOT NULL,
+    network TEXT NULL,
+    key_type TEXT NULL,
+    refresh_token_hash TEXT UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_sessions_address ON sessions (address);
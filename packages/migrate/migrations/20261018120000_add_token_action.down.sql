@@ -0,0 +1 @@
+ALTER TABLE tokens DROP COLUMN action;
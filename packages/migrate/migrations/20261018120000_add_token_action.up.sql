@@ -0,0 +1 @@
+ALTER TABLE tokens ADD COLUMN action TEXT NULL;
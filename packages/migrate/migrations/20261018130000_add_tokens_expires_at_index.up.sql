@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_tokens_expires_at ON tokens (expires_at);
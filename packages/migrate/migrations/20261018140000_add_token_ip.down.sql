@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tokens_address;
+DROP INDEX IF EXISTS idx_tokens_ip;
+ALTER TABLE tokens DROP COLUMN ip;
@@ -0,0 +1,3 @@
+ALTER TABLE tokens ADD COLUMN ip TEXT NULL;
+CREATE INDEX IF NOT EXISTS idx_tokens_ip ON tokens (ip) WHERE used = false;
+CREATE INDEX IF NOT EXISTS idx_tokens_address ON tokens (address) WHERE used = false;
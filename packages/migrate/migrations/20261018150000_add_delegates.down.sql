@@ -0,0 +1,2 @@
+ALTER TABLE tokens DROP COLUMN delegate;
+DROP TABLE IF EXISTS delegates;
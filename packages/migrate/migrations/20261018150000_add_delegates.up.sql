@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS delegates (
+    id SERIAL PRIMARY KEY,
+    owner TEXT NOT NULL,
+    address TEXT NOT NULL,
+    role TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (owner, address)
+);
+
+CREATE INDEX idx_delegates_address ON delegates (address);
+
+ALTER TABLE tokens ADD COLUMN delegate TEXT NULL;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    id TEXT PRIMARY KEY,
+    owner TEXT NOT NULL,
+    name TEXT NULL,
+    key_hash TEXT UNIQUE NOT NULL,
+    permissions TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_api_keys_owner ON api_keys (owner);
+
+ALTER TABLE tokens ADD COLUMN api_key_id TEXT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_assets_search;
+ALTER TABLE assets DROP COLUMN search;
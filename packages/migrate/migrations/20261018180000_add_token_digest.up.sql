@@ -0,0 +1 @@
+ALTER TABLE tokens ADD COLUMN digest TEXT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_assets_deleted_at;
+ALTER TABLE assets DROP COLUMN deleted_at;
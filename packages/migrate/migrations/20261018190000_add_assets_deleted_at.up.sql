@@ -0,0 +1,4 @@
+ALTER TABLE assets ADD COLUMN deleted_at TIMESTAMPTZ NULL;
+
+-- The purge job looks up the deleted assets, a small part of the table.
+CREATE INDEX idx_assets_deleted_at ON assets (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,15 @@
+-- Revisions are deleted with their asset when it's purged, by the purge job.
+CREATE TABLE IF NOT EXISTS asset_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    asset_id TEXT NOT NULL,
+    revision INT NOT NULL,
+    operation TEXT NOT NULL,
+    address TEXT NOT NULL,
+    auth_method TEXT NOT NULL,
+    nonce TEXT NULL,
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (asset_id, revision)
+);
+
+CREATE INDEX idx_asset_revisions_created_at ON asset_revisions (asset_id, created_at);
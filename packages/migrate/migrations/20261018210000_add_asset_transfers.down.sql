@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS asset_transfers;
+ALTER TABLE tokens DROP COLUMN recipient;
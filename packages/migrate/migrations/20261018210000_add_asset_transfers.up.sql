@@ -0,0 +1,20 @@
+ALTER TABLE tokens ADD COLUMN recipient TEXT NULL;
+
+-- Transfers are deleted with their asset when it's purged, by the purge job.
+CREATE TABLE IF NOT EXISTS asset_transfers (
+    id BIGSERIAL PRIMARY KEY,
+    asset_id TEXT NOT NULL,
+    from_address TEXT NOT NULL,
+    to_address TEXT NOT NULL,
+    status TEXT NOT NULL,
+    offer_nonce TEXT NULL,
+    accept_nonce TEXT NULL,
+    cancelled_by TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_asset_transfers_asset_id ON asset_transfers (asset_id, created_at);
+-- An asset has at most one pending offer.
+CREATE UNIQUE INDEX idx_asset_transfers_pending ON asset_transfers (asset_id) WHERE status = 'pending';
@@ -0,0 +1 @@
+ALTER TABLE assets ADD COLUMN version INT NOT NULL DEFAULT 1;